@@ -16,6 +16,15 @@ const (
 	PluginOldNameOpenShiftConformance  = "openshift-conformance-validated"
 )
 
+// ConformancePlugins are the plugins running conformance tests, which failures are
+// processed by the filter pipeline, in the order of the execution.
+var ConformancePlugins = []string{
+	PluginNameOpenShiftUpgrade,
+	PluginNameKubernetesConformance,
+	PluginNameOpenShiftConformance,
+	PluginNameConformanceReplay,
+}
+
 type PluginDefinition struct {
 	PluginImage   string `json:"pluginImage"`
 	SonobuoyImage string `json:"sonobuoyImage"`
@@ -52,48 +61,29 @@ type OPCTPluginSummary struct {
 	// Those tests must raise attention and alerts.
 	FailedFiltered []string
 
-	// Filter SuiteOnly:
-	// FailedFilter1 is the list of failures (A) included only in the original suite (B): A INTERSECTION B
-	// FailedFilterSuite     []string
-	FailedFilter1         []string
-	FailedExcludedFilter1 []string
-
-	// Filter Baseline:
-	// FailedFilter2 is the list of failures (A) excluding the baseline(B): A EXCLUDE B
-	// FailedFilterBaseline  []string
-	FailedFilter2         []string
-	FailedExcludedFilter2 []string
-
-	// Filter FlakeAPI:
-	// FailedFilter3 is the priority list of failures - not reporting as flake in OpenShift CI.
-	// FailedFilterPrio      []string
-	FailedFilter3         []string
-	FailedExcludedFilter3 []string
-
-	// Filter BaselineAPI:
-	// FailedFilter4 is the list after excluding known failures from OPCT CI.
-	// This filter is similar BaseLine, but it's a list of failures collected from
-	// processed data (another OPCT execution) on OPCT CI after processed by OPCT report,
-	// exposed thorugh the OPCT API. This list is used to exclude known failures,
-	// to prevent false positives on the review pipeline.
-	// TODO(mtulio): deprecate Filter2 when Filter4 is accurated. Baseline results should
-	// not use Filter2.
-	FailedFilter4         []string
-	FailedExcludedFilter4 []string
-
-	// Filter KnownFailures:
-	// FailedFilter5 is the list of failures that are explicitly removed from pipeline.
-	// It should not be used to exclude failures from the report of e2e included in suite,
-	// but to remove known flake/failures that is not relevant to the pipeline.
-	// Example: '[sig-arch] External binary usage'
-	// Filter5KnownFailures  []string
-	FailedFilter5         []string
-	FailedExcludedFilter5 []string
-
-	// Filter Replay:
-	// FailedFilter6 is the list of failures which also failed in the second shot: replay plugin/step.
-	FailedFilter6         []string
-	FailedExcludedFilter6 []string
+	// Filters is the ordered list of results for each stage of the filter pipeline.
+	// Each stage receives the failures from the previous one, the first stage
+	// receives the FailedList.
+	Filters []*FilterStage
+}
+
+// FilterStage holds the results of one filter in the failure pipeline for a plugin.
+type FilterStage struct {
+	// ID is the filter identifier (see FilterName* constants).
+	ID string
+
+	// Description is a short description of the filter used by the report.
+	Description string
+
+	// Skipped is set when the filter is not applied to the plugin, copying
+	// the failures from the previous stage.
+	Skipped bool
+
+	// Failures is the list of failures kept by the filter, moving forward in the pipeline.
+	Failures []string
+
+	// Excluded is the list of failures removed by the filter.
+	Excluded []string
 }
 
 func (ps *OPCTPluginSummary) calculateErrorCounter() *archive.ErrorCounter {
@@ -135,65 +125,59 @@ const (
 	// FilterNameReplay is the filter to exclude failures which are passing the replay step.
 	FilterNameReplay = "replay"
 
+	// FilterNameBaselineAPI is the filter to exclude failures reported in the baseline
+	// results served by the OPCT API (OPCT CI).
+	FilterNameBaselineAPI = "baseline-api"
+
 	// FilterNameFinalCopy is the last step in the filter pipeline to copy the final list of failures
 	// to be used to compose the final report/data.
 	FilterNameFinalCopy = "copy"
 )
 
+// GetFilterStage returns the stage of the pipeline by filter ID, or nil when
+// the filter has not been processed for the plugin.
+func (ps *OPCTPluginSummary) GetFilterStage(filterID string) *FilterStage {
+	for _, stage := range ps.Filters {
+		if stage.ID == filterID {
+			return stage
+		}
+	}
+	return nil
+}
+
 // GetFailuresByFilterID returns the list of failures handlers by filter ID.
 func (ps *OPCTPluginSummary) GetFailuresByFilterID(filterID string) ([]string, []string) {
-	switch filterID {
-	case FilterNameSuiteOnly:
-		return ps.FailedFilter1, ps.FailedExcludedFilter1
-	case FilterNameBaseline:
-		return ps.FailedFilter2, ps.FailedExcludedFilter2
-	case FilterNameKF:
-		return ps.FailedFilter5, ps.FailedExcludedFilter5
-	case FilterNameReplay:
-		return ps.FailedFilter6, ps.FailedExcludedFilter6
+	stage := ps.GetFilterStage(filterID)
+	if stage == nil {
+		return nil, nil
 	}
-	return nil, nil
+	return stage.Failures, stage.Excluded
 }
 
-// SetFailuresByFilterID stes the list of failures handlers by filter ID.
+// SetFailuresByFilterID sets the list of failures handlers by filter ID, appending
+// a new stage to the end of the pipeline when the filter has not been processed.
 func (ps *OPCTPluginSummary) SetFailuresByFilterID(filterID string, failures []string, excluded []string) {
-	switch filterID {
-	case FilterNameSuiteOnly:
-		ps.FailedFilter1 = failures
-		ps.FailedExcludedFilter1 = excluded
-		return
-	case FilterNameBaseline:
-		ps.FailedFilter2 = failures
-		ps.FailedExcludedFilter2 = excluded
-		return
-	case FilterNameKF:
-		ps.FailedFilter5 = failures
-		ps.FailedExcludedFilter5 = excluded
-		return
-	case FilterNameReplay:
-		ps.FailedFilter6 = failures
-		ps.FailedExcludedFilter6 = excluded
-		return
+	stage := ps.GetFilterStage(filterID)
+	if stage == nil {
+		stage = &FilterStage{ID: filterID}
+		ps.Filters = append(ps.Filters, stage)
 	}
+	stage.Failures = failures
+	stage.Excluded = excluded
 }
 
-// GetPreviousFailuresByFilterID returns the list of failures from the previous plugin
+// GetPreviousFailuresByFilterID returns the list of failures from the previous filter
 // in the pipeline, by providing the current filter ID.
-// TODO: move the filter logic to a dedicated structure using linked stack/list,
-// allowing each plugin having a dynamic list of filters, instead of forcing the same
-// pipeline across all plugins.
+// The first filter in the pipeline receives the original list of failures, and the
+// FilterNameFinalCopy (or any filter not processed yet) receives the failures from the
+// last filter processed.
 func (ps *OPCTPluginSummary) GetPreviousFailuresByFilterID(filterID string) []string {
-	switch filterID {
-	case FilterNameSuiteOnly:
-		return nil
-	case FilterNameKF:
-		return ps.FailedFilter1 // SuiteOnly
-	case FilterNameReplay:
-		return ps.FailedFilter5 // KnownFailures
-	case FilterNameBaseline:
-		return ps.FailedFilter6 // Replay
-	case FilterNameFinalCopy:
-		return ps.FailedFilter4 // BaselineAPI
+	previous := ps.FailedList
+	for _, stage := range ps.Filters {
+		if stage.ID == filterID {
+			return previous
+		}
+		previous = stage.Failures
 	}
-	return nil
+	return previous
 }
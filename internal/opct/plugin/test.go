@@ -42,10 +42,76 @@ type TestItem struct {
 
 	// Reference for documentation.
 	Documentation string `json:"documentation"`
+
+	// Baseline is the failure frequency of the test in the baseline results (BaselineAPI).
+	Baseline *BaselineFrequency `json:"baseline,omitempty"`
+
+	// Decisions is the trace of the filter pipeline decisions to the failed test, in the
+	// order the filters were applied.
+	Decisions []*FilterDecision `json:"decisions,omitempty"`
 }
 
 type Tests map[string]*TestItem
 
+// BaselineFrequency is the number of baseline results the test failed.
+type BaselineFrequency struct {
+	// Failures is the number of baselines the test failed.
+	Failures int `json:"failures"`
+
+	// Total is the number of baselines evaluated.
+	Total int `json:"total"`
+}
+
+// Share returns the fraction of the baselines the test failed.
+func (bf *BaselineFrequency) Share() float64 {
+	if bf.Total == 0 {
+		return 0
+	}
+	return float64(bf.Failures) / float64(bf.Total)
+}
+
+// String returns the frequency in the format failures/total.
+func (bf *BaselineFrequency) String() string {
+	return fmt.Sprintf("%d/%d", bf.Failures, bf.Total)
+}
+
+// Decisions of a filter to a failed test.
+const (
+	FilterDecisionKept     = "kept"
+	FilterDecisionExcluded = "excluded"
+)
+
+// FilterDecision is the record of the decision of a filter to a failed test in the pipeline.
+type FilterDecision struct {
+	// Filter is the ID of the filter.
+	Filter string `json:"filter"`
+
+	// Decision is the result: kept (moved forward in the pipeline) or excluded.
+	Decision string `json:"decision"`
+
+	// Reason is a short explanation of the decision.
+	Reason string `json:"reason"`
+
+	// Evidence holds the data supporting the decision. Example: flake percentage, baseline name.
+	Evidence map[string]string `json:"evidence,omitempty"`
+}
+
+// AddDecision appends the filter decision to the test trace.
+func (pi *TestItem) AddDecision(d *FilterDecision) {
+	pi.Decisions = append(pi.Decisions, d)
+}
+
+// GetDecision returns the decision of the filter to the test, or nil when the filter
+// did not process the test.
+func (pi *TestItem) GetDecision(filterID string) *FilterDecision {
+	for _, d := range pi.Decisions {
+		if d.Filter == filterID {
+			return d
+		}
+	}
+	return nil
+}
+
 // UpdateErrorCounter reads the failures and stdout looking for error patterns from
 // a specific test, accumulating the ErrorCounters structure.
 func (pi *TestItem) UpdateErrorCounter() {
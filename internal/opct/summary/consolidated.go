@@ -4,10 +4,10 @@ package summary
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"regexp"
-	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -27,6 +27,86 @@ type ConsolidatedSummary struct {
 	Provider    *ResultSummary
 	Baseline    *ResultSummary
 	BaselineAPI *baseline.BaselineConfig
+
+	// Pipeline is the ordered list of filters applied to the failures.
+	Pipeline FilterPipeline
+
+	// KnownFailuresFile is the path of the user-provided known failures file.
+	KnownFailuresFile string
+
+	// SippyOptions are the options of the Sippy API client used by the flake filter.
+	SippyOptions *sippy.Options
+	sippyAPI     *sippy.SippyAPI
+
+	// BaselineAPIOptions are the options of the BaselineAPI filter.
+	BaselineAPIOptions *BaselineAPIOptions
+
+	// ctx is the context of the processing, cancelling the external queries.
+	ctx context.Context
+}
+
+// Default values of the BaselineAPI filter.
+const (
+	DefaultBaselineAPICount           = 5
+	DefaultBaselineAPIMinFailureShare = 0.5
+)
+
+// BaselineAPIOptions are the options of the BaselineAPI filter, aggregating the
+// most recent baseline results of the release and platform.
+type BaselineAPIOptions struct {
+	// Count is the number of most recent baselines to aggregate, must be positive.
+	Count int `json:"count"`
+
+	// MinFailureShare is the minimum share (0-1] of the baselines a test must have failed
+	// to be excluded from the failures.
+	MinFailureShare float64 `json:"minFailureShare"`
+
+	// URL is the URL of the Baseline API (store) serving the baseline results.
+	// Default: OPCT results API.
+	URL string `json:"url,omitempty"`
+
+	// Baselines are the names of the baseline summaries aggregated by the filter.
+	Baselines []string `json:"baselines,omitempty"`
+
+	// IntegrityWarnOnly logs a warning, instead of failing, when a baseline summary
+	// does not match the hash (or signature) recorded in the index.
+	IntegrityWarnOnly bool `json:"integrityWarnOnly,omitempty"`
+
+	// VerifyKey is the path of the public key (ed25519, PEM) to verify the signatures
+	// of the baseline summaries. Unsigned summaries are rejected when set.
+	VerifyKey string `json:"verifyKey,omitempty"`
+}
+
+// NewDefaultBaselineAPIOptions returns the default options of the BaselineAPI filter.
+func NewDefaultBaselineAPIOptions() *BaselineAPIOptions {
+	return &BaselineAPIOptions{
+		Count:           DefaultBaselineAPICount,
+		MinFailureShare: DefaultBaselineAPIMinFailureShare,
+	}
+}
+
+// Validate checks the count and the minimum failure share of the options.
+func (o *BaselineAPIOptions) Validate() error {
+	if o.Count <= 0 {
+		return fmt.Errorf("invalid baseline count %d: must be positive", o.Count)
+	}
+	if o.MinFailureShare <= 0 || o.MinFailureShare > 1 {
+		return fmt.Errorf("invalid baseline minimum failure share %v: must be in the range (0-1]", o.MinFailureShare)
+	}
+	return nil
+}
+
+// GetIntegrityOptions returns the options to verify the baseline summaries.
+func (o *BaselineAPIOptions) GetIntegrityOptions() (*baseline.IntegrityOptions, error) {
+	opts := &baseline.IntegrityOptions{WarnOnly: o.IntegrityWarnOnly}
+	if o.VerifyKey != "" {
+		key, err := baseline.LoadVerifyKey(o.VerifyKey)
+		if err != nil {
+			return nil, err
+		}
+		opts.PublicKey = key
+	}
+	return opts, nil
 }
 
 type ConsolidatedSummaryInput struct {
@@ -35,12 +115,49 @@ type ConsolidatedSummaryInput struct {
 	SaveTo      string
 	Verbose     bool
 	Timers      *metrics.Timers
+
+	// FilterPipeline overrides the DefaultFilterPipeline when set.
+	FilterPipeline FilterPipeline
+
+	// KnownFailuresFile is the path of the known failures file (--known-failures).
+	KnownFailuresFile string
+
+	// SippyOptions are the options of the Sippy API client (flake data).
+	SippyOptions *sippy.Options
+
+	// Context is the context of the processing. Default: context.Background().
+	Context context.Context
+
+	// BaselineAPIOptions are the options of the BaselineAPI filter.
+	// Default: NewDefaultBaselineAPIOptions().
+	BaselineAPIOptions *BaselineAPIOptions
 }
 
-func NewConsolidatedSummary(in *ConsolidatedSummaryInput) *ConsolidatedSummary {
+// NewConsolidatedSummary creates the summary from the input, validating the options.
+func NewConsolidatedSummary(in *ConsolidatedSummaryInput) (*ConsolidatedSummary, error) {
+	pipeline := DefaultFilterPipeline
+	if len(in.FilterPipeline) > 0 {
+		pipeline = in.FilterPipeline
+	}
+	ctx := in.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	baselineOpts := NewDefaultBaselineAPIOptions()
+	if in.BaselineAPIOptions != nil {
+		*baselineOpts = *in.BaselineAPIOptions
+	}
+	if err := baselineOpts.Validate(); err != nil {
+		return nil, err
+	}
 	return &ConsolidatedSummary{
-		Verbose: in.Verbose,
-		Timers:  in.Timers,
+		ctx:                ctx,
+		BaselineAPIOptions: baselineOpts,
+		Pipeline:           pipeline,
+		KnownFailuresFile:  in.KnownFailuresFile,
+		SippyOptions:       in.SippyOptions,
+		Verbose:            in.Verbose,
+		Timers:             in.Timers,
 		Provider: &ResultSummary{
 			Name:      ResultSourceNameProvider,
 			Archive:   in.Archive,
@@ -63,7 +180,7 @@ func NewConsolidatedSummary(in *ConsolidatedSummaryInput) *ConsolidatedSummary {
 			},
 		},
 		BaselineAPI: &baseline.BaselineConfig{},
-	}
+	}, nil
 }
 
 // Process entrypoint to read and fill all summaries for each archive, plugin and suites
@@ -85,39 +202,7 @@ func (cs *ConsolidatedSummary) Process() error {
 	}
 
 	// Filters pipeline (order matters)
-	log.Debug("Processing results/Applying filters/Suite")
-	cs.Timers.Set("cs-process/filter1-suite")
-	if err := cs.applyFilterSuite(); err != nil {
-		return err
-	}
-
-	log.Debug("Processing results/Applying filters/Known Failures")
-	cs.Timers.Set("cs-process/filter5-known-failures")
-	if err := cs.applyFilterKnownFailures(plugin.FilterNameKF); err != nil {
-		return err
-	}
-
-	log.Debug("Processing results/Applying filters/6/Replay")
-	cs.Timers.Set("cs-process/filter-replay")
-	if err := cs.applyFilterReplay(plugin.FilterNameReplay); err != nil {
-		return err
-	}
-
-	log.Debug("Processing results/Applying filters/2/Baseline")
-	cs.Timers.Set("cs-process/filter2-baseline")
-	if err := cs.applyFilterBaseline(plugin.FilterNameBaseline); err != nil {
-		return err
-	}
-
-	log.Debug("Processing results/Applying filters/3/Flake")
-	cs.Timers.Set("cs-process/filter3-flake")
-	if err := cs.applyFilterFlaky(plugin.FilterNameFlaky); err != nil {
-		return err
-	}
-
-	log.Debug("Processing results/Applying filters/4/Baseline API")
-	cs.Timers.Set("cs-process/filter4-baseline-api")
-	if err := cs.applyFilterBaselineAPI(); err != nil {
+	if err := cs.applyFilterPipeline(); err != nil {
 		return err
 	}
 
@@ -148,267 +233,30 @@ func (cs *ConsolidatedSummary) GetBaseline() *ResultSummary {
 	return cs.Baseline
 }
 
-// HasBaselineResults checks if the baseline results was set (--dif),
-// and has valid data.
-func (cs *ConsolidatedSummary) HasBaselineResults() bool {
-	if cs.Baseline == nil {
-		return false
-	}
-	return cs.Baseline.HasValidResults()
-}
-
-// Filter1: Suite
-// applyFilterSuite process the FailedList for each plugin, getting **intersection** tests
-// for respective suite.
-func (cs *ConsolidatedSummary) applyFilterSuite() error {
-	for _, pluginName := range []string{
-		plugin.PluginNameOpenShiftUpgrade,
-		plugin.PluginNameKubernetesConformance,
-		plugin.PluginNameOpenShiftConformance,
-		plugin.PluginNameConformanceReplay,
-	} {
-		if err := cs.applyFilterSuiteForPlugin(pluginName); err != nil {
-			return fmt.Errorf("error while processing filter1 (SuiteOnly): %w", err)
-		}
-	}
-	return nil
-}
-
-// applyFilterSuiteForPlugin calculates the intersection of Provider Failed AND suite
-func (cs *ConsolidatedSummary) applyFilterSuiteForPlugin(pluginName string) error {
-	var ps *plugin.OPCTPluginSummary
-	var pluginSuite *OpenshiftTestsSuite
-
-	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-		pluginSuite = cs.GetProvider().GetSuites().KubernetesConformance
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-		pluginSuite = cs.GetProvider().GetSuites().OpenshiftConformance
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-		pluginSuite = &OpenshiftTestsSuite{}
-
-	case plugin.PluginNameConformanceReplay:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-		pluginSuite = &OpenshiftTestsSuite{}
-	}
-
-	e2eFailures := ps.FailedList
-	e2eSuite := pluginSuite.Tests
-	emptySuite := len(pluginSuite.Tests) == 0
-	hashSuite := make(map[string]struct{}, len(e2eSuite))
-
-	for _, v := range e2eSuite {
-		hashSuite[v] = struct{}{}
-	}
-
-	for _, v := range e2eFailures {
-		// move on the pipeline when the suite is empty.
-		ps.Tests[v].State = "filter1SuiteOnly"
-
-		// Skip when the suite has no tests or issues when collecting the counter.
-		if emptySuite {
-			ps.FailedFilter1 = append(ps.FailedFilter1, v)
-			continue
-		}
-		// save the test in suite, and excluded ones.
-		if _, ok := hashSuite[v]; ok {
-			ps.FailedFilter1 = append(ps.FailedFilter1, v)
-			continue
-		}
-		ps.FailedExcludedFilter1 = append(ps.FailedExcludedFilter1, v)
-	}
-	sort.Strings(ps.FailedFilter1)
-
-	log.Debugf("Filter (SuiteOnly) results: plugin=%s in=failures(%d) in=suite(%d) out=filter(%d) filterExcluded(%d)",
-		pluginName, len(e2eFailures), len(e2eSuite),
-		len(ps.FailedFilter1), len(ps.FailedExcludedFilter1))
-	return nil
-}
-
-// Filter2: Baseline archive
-// applyFilterBaseline process the FailedFilterSuite for each plugin, **excluding** failures from
-// baseline test.
-func (cs *ConsolidatedSummary) applyFilterBaseline(filterID string) error {
-	for _, pluginName := range []string{
-		plugin.PluginNameOpenShiftUpgrade,
-		plugin.PluginNameKubernetesConformance,
-		plugin.PluginNameOpenShiftConformance,
-		plugin.PluginNameConformanceReplay,
-	} {
-		if err := cs.applyFilterBaselineForPlugin(pluginName, filterID); err != nil {
-			return fmt.Errorf("error while processing filter2 (baseline archive): %w", err)
-		}
+// Context returns the context of the processing.
+func (cs *ConsolidatedSummary) Context() context.Context {
+	if cs.ctx == nil {
+		return context.Background()
 	}
-	return nil
+	return cs.ctx
 }
 
-// applyFilterBaselineForPlugin calculates the **exclusion** tests of
-// Provider Failed included on suite and Baseline failed tests.
-func (cs *ConsolidatedSummary) applyFilterBaselineForPlugin(pluginName string, filterID string) error {
-	var ps *plugin.OPCTPluginSummary
-	var e2eFailuresBaseline []string
-
-	// TODO: replace the baseline from discovered data from API (s3). The flag
-	// OPCT_DISABLE_EXP_BASELINE_API can be set to use the local file.
-	// Default method is to use the API to get the baseline.
-
-	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-		if cs.GetBaseline().HasValidResults() {
-			e2eFailuresBaseline = cs.GetBaseline().GetOpenShift().GetResultK8SValidated().FailedList
-		}
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-		if cs.GetBaseline().HasValidResults() {
-			e2eFailuresBaseline = cs.GetBaseline().GetOpenShift().GetResultOCPValidated().FailedList
-		}
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-
-	case plugin.PluginNameConformanceReplay:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-
-	default:
-		return errors.New("Suite not found to apply filter: Flaky")
-	}
-
-	filterFailures, filterFailuresExcluded := ps.GetFailuresByFilterID(filterID)
-	e2eFailuresProvider := ps.GetPreviousFailuresByFilterID(filterID)
-	hashBaseline := make(map[string]struct{}, len(e2eFailuresBaseline))
-
-	for _, v := range e2eFailuresBaseline {
-		hashBaseline[v] = struct{}{}
-	}
-
-	// DEPRECATION warning when used:
-	if len(e2eFailuresBaseline) > 0 {
-		log.Warnf("Filter baseline (--diff|--baseline) is deprecated and will be removed soon, the filter BaselineAPI is replacing and automatically applied to the failure pipeline.")
-	}
-	for _, v := range e2eFailuresProvider {
-		ps.Tests[v].State = "filter2Baseline"
-		if _, ok := hashBaseline[v]; !ok {
-			filterFailures = append(filterFailures, v)
-			continue
-		}
-		filterFailuresExcluded = append(filterFailuresExcluded, v)
-	}
-	sort.Strings(filterFailures)
-	ps.SetFailuresByFilterID(filterID, filterFailures, filterFailuresExcluded)
-
-	log.Debugf("Filter (Baseline) results: plugin=%s in=filter(%d) out=filter(%d) filterExcluded(%d)",
-		pluginName, len(e2eFailuresProvider),
-		len(filterFailures), len(filterFailuresExcluded))
-	return nil
-}
-
-// Filter3: Flaky
-// applyFilterFlaky process the FailedFilterSuite for each plugin, **excluding** failures from
-// baseline test.
-func (cs *ConsolidatedSummary) applyFilterFlaky(filterID string) error {
-	if err := cs.applyFilterFlakeForPlugin(plugin.PluginNameKubernetesConformance, filterID); err != nil {
-		return err
-	}
-	if err := cs.applyFilterFlakeForPlugin(plugin.PluginNameOpenShiftConformance, filterID); err != nil {
-		return err
-	}
-	return nil
-}
-
-// applyFilterFlakeForPlugin query the Sippy API looking for each failed test
-// on each plugin/suite, saving the list on the ResultSummary.
-func (cs *ConsolidatedSummary) applyFilterFlakeForPlugin(pluginName string, filterID string) error {
-	var ps *plugin.OPCTPluginSummary
-
-	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-
-	case plugin.PluginNameConformanceReplay:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-
-	default:
-		return errors.New("Suite not found to apply filter: Flaky")
-	}
-
-	// TODO: define if we will check for flakes for all failures or only filtered
-	// Query Flaky only the FilteredBaseline to avoid many external queries.
-	ver, err := cs.GetProvider().GetOpenShift().GetClusterVersionXY()
-	if err != nil {
-		return errors.Errorf("Error getting cluster version: %v", err)
-	}
-
-	api := sippy.NewSippyAPI(ver)
-	for _, name := range ps.FailedFilter2 {
-		ps.Tests[name].State = "filter3FlakeCheck"
-		resp, err := api.QueryTests(&sippy.SippyTestsRequestInput{TestName: name})
-		if err != nil {
-			log.Errorf("#> Error querying to Sippy API: %v", err)
-			ps.FailedFilter3 = append(ps.FailedFilter3, name)
-			continue
-		}
-		if resp == nil {
-			log.Errorf("Error filter flakeAPI: invalid response: %v", resp)
-			ps.FailedFilter3 = append(ps.FailedFilter3, name)
-			continue
-		}
-		for _, r := range *resp {
-			if _, ok := ps.Tests[name]; ok {
-				ps.Tests[name].Flake = &r
-			} else {
-				ps.Tests[name] = &plugin.TestItem{
-					Name:  name,
-					Flake: &r,
-				}
-			}
-			// Applying flake filter by moving only non-flakes to the pipeline.
-			// The tests reporing lower than 5% of CurrentFlakePerc by Sippy are selected as non-flake.
-			// TODO: Review flake severity
-			if ps.Tests[name].Flake.CurrentFlakePerc <= 5.0 {
-				ps.Tests[name].State = "filter3Priority"
-				ps.FailedFilter3 = append(ps.FailedFilter3, name)
-				continue
-			}
-			ps.Tests[name].State = "filter3Flake"
-			ps.FailedExcludedFilter3 = append(ps.FailedExcludedFilter3, name)
-		}
+// GetFlakeDataSource returns the data source stats of the flake data used by
+// the filter pipeline, or nil when the flake filter was not used.
+func (cs *ConsolidatedSummary) GetFlakeDataSource() *sippy.DataSourceStats {
+	if cs.sippyAPI == nil {
+		return nil
 	}
-	sort.Strings(ps.FailedFilter3)
-
-	log.Debugf("Filter (FlakeAPI) results: plugin=%s in=filter(%d) out=filter(%d) filterExcluded(%d)",
-		pluginName, len(ps.FailedFilter2),
-		len(ps.FailedFilter3), len(ps.FailedExcludedFilter3))
-	return nil
+	return cs.sippyAPI.GetStats()
 }
 
-// Filter4: Baseline API
-func (cs *ConsolidatedSummary) applyFilterBaselineAPI() error {
-	// Load baseline results from API
-	if err := cs.loadBaselineFromAPI(); err != nil {
-		return fmt.Errorf("loading baseline results from API: %w", err)
-	}
-	for _, pluginName := range []string{
-		plugin.PluginNameOpenShiftUpgrade,
-		plugin.PluginNameKubernetesConformance,
-		plugin.PluginNameOpenShiftConformance,
-		plugin.PluginNameConformanceReplay,
-	} {
-		if err := cs.applyFilterBaselineAPIForPlugin(pluginName); err != nil {
-			return fmt.Errorf("error while processing filter4 (baseline API): %w", err)
-		}
+// HasBaselineResults checks if the baseline results was set (--dif),
+// and has valid data.
+func (cs *ConsolidatedSummary) HasBaselineResults() bool {
+	if cs.Baseline == nil {
+		return false
 	}
-	return nil
+	return cs.Baseline.HasValidResults()
 }
 
 // loadBaselineFromAPI query the the OPCT "backend" looking for the baseline results.
@@ -437,254 +285,33 @@ func (cs *ConsolidatedSummary) loadBaselineFromAPI() error {
 	platformType := cs.Provider.OpenShift.GetInfrastructurePlatformType()
 
 	cs.BaselineAPI = baseline.NewBaselineReportSummary()
-	if err := cs.BaselineAPI.GetLatestRawSummaryFromPlatformWithFallback(ocpRelease, platformType); err != nil {
-		return errors.Wrap(err, "failed to get baseline from API")
-	}
-	return nil
-}
-
-// applyFilterBaselineAPIForPlugin check the Sippy API looking for each failed test
-// on each plugin/suite, saving the list on the ResultSummary.
-// The filter must populate the FailedFilter4 and FailedExcludedFilter4.
-func (cs *ConsolidatedSummary) applyFilterBaselineAPIForPlugin(pluginName string) error {
-	// log.Warnf("TODO: implement applyFilterBaselineAPIForPlugin: %s", pluginName)
-	var ps *plugin.OPCTPluginSummary
-	var e2eFailuresBaseline []string
-	var err error
-
-	// TODO: replace the baseline from discovered data from API (s3). The flag
-	// OPCT_DISABLE_EXP_BASELINE_API can be set to use the local file.
-	// Default method is to use the API to get the baseline.
-
-	skipFilter := false
-	if os.Getenv("OPCT_DISABLE_FILTER_BASELINE") == "1" {
-		skipFilter = true
-	}
-
-	doneFilter := func() {
-		log.Debugf("Filter (BaselineAPI) results: plugin=%s in=filter(%d) inApi=(%d) out=filter(%d) excluded(%d)",
-			pluginName, len(ps.FailedFilter3), len(e2eFailuresBaseline),
-			len(ps.FailedFilter4), len(ps.FailedExcludedFilter4))
-	}
-
-	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-
-	case plugin.PluginNameConformanceReplay:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-		ps.FailedFilter4 = ps.FailedFilter3
-		doneFilter()
-		return nil
-
-	default:
-		return fmt.Errorf("plugin not found")
-	}
-
-	b := cs.BaselineAPI.GetBuffer()
-	if b != nil {
-		e2eFailuresBaseline, err = b.GetPriorityFailuresFromPlugin(pluginName)
+	if cs.BaselineAPIOptions.URL != "" {
+		cs.BaselineAPI, err = baseline.NewBaselineReportSummaryFromURL(cs.BaselineAPIOptions.URL)
 		if err != nil {
-			log.Errorf("failed to get priority failures from plugin: %v", err)
-		}
-	}
-
-	e2eFailuresPipeline := ps.FailedFilter3
-	hashBaseline := make(map[string]struct{}, len(e2eFailuresPipeline))
-
-	for _, v := range e2eFailuresBaseline {
-		hashBaseline[v] = struct{}{}
-	}
-
-	for _, v := range e2eFailuresPipeline {
-		ps.Tests[v].State = "filter4BaselineAPI"
-		if _, ok := hashBaseline[v]; !ok {
-			ps.FailedFilter4 = append(ps.FailedFilter4, v)
-			continue
-		}
-		ps.FailedExcludedFilter4 = append(ps.FailedExcludedFilter4, v)
-	}
-
-	// feed the pipeline with the same tests when the filter is disabled.
-	if skipFilter {
-		log.Warn("Filter pipeline: Basline API is explicitly disabled by OPCT_DISABLE_FILTER_BASELINE, using Filter3 to keep processing failures")
-		ps.FailedFilter4 = ps.FailedFilter3
-	}
-	sort.Strings(ps.FailedFilter4)
-	doneFilter()
-	return nil
-}
-
-// Filter5: Known Failures
-// applyFilterKnownFailures skip well known failures that are not relevant to the validation process.
-func (cs *ConsolidatedSummary) applyFilterKnownFailures(filterID string) error {
-	// Reason to skip the test:
-	// "[sig-arch] External binary usage" :
-	//  - The test is not relevant to the validation process, and it's not a real failure
-	//    since the k8s/conformance suite is executed correctly.
-	// "[sig-mco] Machine config pools complete upgrade" :
-	//  - The test is not relevant to the validation process, the custom MCP is used
-	//    in the OPCT topology to executed in-cluster validation. If MCP is not used,
-	//    the test environment would be evicted when the dedicated node is drained.
-	cs.Provider.TestSuiteKnownFailures = []string{
-		"[sig-arch] External binary usage",
-		"[sig-mco] Machine config pools complete upgrade",
-	}
-
-	for _, pluginName := range []string{
-		plugin.PluginNameOpenShiftUpgrade,
-		plugin.PluginNameKubernetesConformance,
-		plugin.PluginNameOpenShiftConformance,
-		plugin.PluginNameConformanceReplay,
-	} {
-		if err := cs.applyFilterKnownFailuresForPlugin(pluginName, filterID); err != nil {
-			return fmt.Errorf("error while processing filter5 (baseline API): %w", err)
-		}
-	}
-	return nil
-}
-
-// Filter5 by plugin
-func (cs *ConsolidatedSummary) applyFilterKnownFailuresForPlugin(pluginName string, filterID string) error {
-	var ps *plugin.OPCTPluginSummary
-
-	// Get the list of the last filter in the pipeline
-	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-
-	case plugin.PluginNameConformanceReplay:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-
-	default:
-		return fmt.Errorf("error while processing filter5 (know failures), plugin not found: %s", pluginName)
-	}
-
-	// read the failures from pipeline
-	filterFailures, filterFailuresExcluded := ps.GetFailuresByFilterID(filterID)
-	e2eFailuresPipeline := ps.GetPreviousFailuresByFilterID(filterID)
-	hashExclusion := make(map[string]struct{}, len(cs.Provider.TestSuiteKnownFailures))
-
-	for _, v := range cs.Provider.TestSuiteKnownFailures {
-		hashExclusion[v] = struct{}{}
-	}
-
-	for _, v := range e2eFailuresPipeline {
-		ps.Tests[v].State = "filter5KnownFailures"
-		if _, ok := hashExclusion[v]; !ok {
-			filterFailures = append(filterFailures, v)
-			continue
-		}
-		filterFailuresExcluded = append(filterFailuresExcluded, v)
-	}
-	sort.Strings(filterFailures)
-	ps.SetFailuresByFilterID(filterID, filterFailures, filterFailuresExcluded)
-
-	log.Debugf("Filter (KF) results: plugin=%s in=filter(%d) out=filter(%d) filterExcluded(%d)",
-		pluginName, len(e2eFailuresPipeline), len(filterFailures), len(filterFailuresExcluded))
-	return nil
-}
-
-// Filter6: Replay
-// applyFilterReplay skip failures that pass in replay, which can be a
-// candidate for flake or false-positive failure.
-// Replay step re-runs the failured tests from conformance suites in serial mode,
-// to check if the test is passing in a second shot.
-func (cs *ConsolidatedSummary) applyFilterReplay(filterID string) error {
-	for _, pluginName := range []string{
-		plugin.PluginNameKubernetesConformance,
-		plugin.PluginNameOpenShiftConformance,
-	} {
-		if err := cs.applyFilterReplayForPlugin(pluginName, filterID); err != nil {
-			return fmt.Errorf("error while processing filter5 (Replay): %w", err)
+			return errors.Wrap(err, "failed to create the BaselineAPI client")
 		}
+		log.Infof("Filter pipeline: using the BaselineAPI from %s", cs.BaselineAPIOptions.URL)
 	}
-	return nil
-}
-
-// Filter6 by plugin
-// applyFilterReplayForPlugin extracts passed tests from replay step, and check
-// if conformance plugins has intersection in its failures, if so the test is passing
-// in the second run, excluding it from the failures.
-func (cs *ConsolidatedSummary) applyFilterReplayForPlugin(pluginName string, filterID string) error {
-	var ps *plugin.OPCTPluginSummary
-	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-
-	default:
-		return fmt.Errorf("plugin not found: %s", pluginName)
-	}
-
-	// read the failures from pipeline
-	filterFailures, filterFailuresExcluded := ps.GetFailuresByFilterID(filterID)
-	e2eFailuresPipeline := ps.GetPreviousFailuresByFilterID(filterID)
-
-	replayPlugin := cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-	if replayPlugin == nil {
-		ps.SetFailuresByFilterID(filterID, filterFailures, filterFailuresExcluded)
-		log.Debugf("Filter (Replay) results: plugin=%s in=filter(%d) out=filter(%d) filterExcluded(%d)",
-			pluginName, len(e2eFailuresPipeline),
-			len(filterFailures), len(filterFailuresExcluded))
-		log.Debugf("skipping filter (Replay) for plugin: %s, no replay results", pluginName)
-		return nil
+	integrity, err := cs.BaselineAPIOptions.GetIntegrityOptions()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the BaselineAPI integrity options")
 	}
-
-	passedReplay := make(map[string]struct{}, len(replayPlugin.Tests))
-	failedReplay := make(map[string]struct{}, len(replayPlugin.Tests))
-	for _, test := range replayPlugin.Tests {
-		name := test.Name
-		if test.Status == "passed" {
-			passedReplay[name] = struct{}{}
-			continue
-		}
-		failedReplay[name] = struct{}{}
+	cs.BaselineAPI.SetIntegrityOptions(integrity)
+	if err := cs.BaselineAPI.GetRecentSummariesFromPlatformWithFallback(ocpRelease, platformType, cs.BaselineAPIOptions.Count); err != nil {
+		return errors.Wrap(err, "failed to get baseline from API")
 	}
-
-	for _, v := range e2eFailuresPipeline {
-		ps.Tests[v].State = "filter6Replay"
-		if _, ok := passedReplay[v]; !ok {
-			filterFailures = append(filterFailures, v)
-			continue
-		}
-		filterFailuresExcluded = append(filterFailuresExcluded, v)
+	for _, b := range cs.BaselineAPI.GetBuffers() {
+		cs.BaselineAPIOptions.Baselines = append(cs.BaselineAPIOptions.Baselines, b.GetName())
 	}
-	sort.Strings(filterFailures)
-	ps.SetFailuresByFilterID(filterID, filterFailures, filterFailuresExcluded)
-
-	log.Debugf("Filter (Replay) results: plugin=%s in=filter(%d) replay=pass(%d) fail(%d) out=filter(%d) filterExcluded(%d)",
-		pluginName, len(e2eFailuresPipeline), len(passedReplay), len(failedReplay),
-		len(filterFailures), len(filterFailuresExcluded))
+	log.Infof("Filter pipeline: using %d baseline results from API: %s", len(cs.BaselineAPIOptions.Baselines), strings.Join(cs.BaselineAPIOptions.Baselines, ", "))
 	return nil
 }
 
 // Filter Final:
-// applyFilterCopyPipeline builds the final failures after filters for each plugin.
+// applyFilterCopyPipeline builds the final failures after filters for each plugin,
+// including the plugins referenced by a custom pipeline.
 func (cs *ConsolidatedSummary) applyFilterCopyPipeline(filterID string) error {
-	for _, pluginName := range []string{
-		plugin.PluginNameOpenShiftUpgrade,
-		plugin.PluginNameKubernetesConformance,
-		plugin.PluginNameOpenShiftConformance,
-		plugin.PluginNameConformanceReplay,
-	} {
+	for _, pluginName := range unionPlugins(plugin.ConformancePlugins, cs.Pipeline.Plugins()) {
 		if err := cs.applyFilterCopyPipelineForPlugin(pluginName, filterID); err != nil {
 			return fmt.Errorf("error while building filtered failures: %w", err)
 		}
@@ -694,32 +321,17 @@ func (cs *ConsolidatedSummary) applyFilterCopyPipeline(filterID string) error {
 
 // applyFilterCopyPipelineForPlugin copy the last filter in the pipeline to the final result of failures.
 func (cs *ConsolidatedSummary) applyFilterCopyPipelineForPlugin(pluginName string, filterID string) error {
-	var ps *plugin.OPCTPluginSummary
+	ps := cs.GetProvider().GetOpenShift().GetResultByName(pluginName)
+	if ps == nil {
+		return nil
+	}
 
-	// Get the list of the last filter in the pipeline
 	switch pluginName {
-	case plugin.PluginNameKubernetesConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultK8SValidated()
-		// Should point to the last filter in the pipeline.
-		ps.FailedFiltered = ps.GetPreviousFailuresByFilterID(filterID)
-
-	case plugin.PluginNameOpenShiftConformance:
-		ps = cs.GetProvider().GetOpenShift().GetResultOCPValidated()
-		// Should point to the last filter in the pipeline.
-		ps.FailedFiltered = ps.GetPreviousFailuresByFilterID(filterID)
-
-	case plugin.PluginNameOpenShiftUpgrade:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceUpgrade()
-		// Should point to the last filter in the pipeline.
-		ps.FailedFiltered = ps.GetPreviousFailuresByFilterID(filterID)
-
 	case plugin.PluginNameConformanceReplay:
-		ps = cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
-		// Should point to the last filter in the pipeline.
 		ps.FailedFiltered = ps.FailedList
-
 	default:
-		return fmt.Errorf("invalid plugin: %s", pluginName)
+		// Should point to the last filter in the pipeline.
+		ps.FailedFiltered = ps.GetPreviousFailuresByFilterID(filterID)
 	}
 
 	log.Debugf("Filter results (Final): plugin=%s filtered failures(%d)", pluginName, len(ps.FailedFiltered))
@@ -757,39 +369,17 @@ func (cs *ConsolidatedSummary) saveResultsPlugin(path, pluginName string) error
 			return err
 		}
 
-		// Save Provider failures with filter: Suite (only)
-		filename = fmt.Sprintf("%s/%s_%s_provider_failures-2-filter1_suite.txt", path, prefix, pluginName)
-		if err := writeFileTestList(filename, resultsProvider.FailedFilter1); err != nil {
-			return err
-		}
-
-		// Save Provider failures with filter: Baseline exclusion
-		filename = fmt.Sprintf("%s/%s_%s_provider_failures-3-filter2_baseline.txt", path, prefix, pluginName)
-		if err := writeFileTestList(filename, resultsProvider.FailedFilter2); err != nil {
-			return err
-		}
-
-		// Save Provider failures with filter: Flaky
-		filename = fmt.Sprintf("%s/%s_%s_provider_failures-4-filter3_without_flakes.txt", path, prefix, pluginName)
-		if err := writeFileTestList(filename, resultsProvider.FailedFilter3); err != nil {
-			return err
-		}
-
-		// Save Provider failures with filter: Baseline API
-		filename = fmt.Sprintf("%s/%s_%s_provider_failures-5-filter4_api.txt", path, prefix, pluginName)
-		if err := writeFileTestList(filename, resultsProvider.FailedFilter4); err != nil {
-			return err
-		}
-
-		// Save Provider failures with filter: Known Failures
-		filename = fmt.Sprintf("%s/%s_%s_provider_failures-5-filter5_knownfailures.txt", path, prefix, pluginName)
-		if err := writeFileTestList(filename, resultsProvider.FailedFilter5); err != nil {
-			return err
+		// Save Provider failures for each filter in the pipeline
+		for idx, stage := range resultsProvider.Filters {
+			filename = fmt.Sprintf("%s/%s_%s_provider_failures-%d-filter_%s.txt", path, prefix, pluginName, idx+2, stage.ID)
+			if err := writeFileTestList(filename, stage.Failures); err != nil {
+				return err
+			}
 		}
 
 		// Save the Providers failures for the latest filter to review (focus on this)
 		filename = fmt.Sprintf("%s/%s_%s_provider_failures.txt", path, prefix, pluginName)
-		if err := writeFileTestList(filename, resultsProvider.FailedFilter3); err != nil {
+		if err := writeFileTestList(filename, resultsProvider.FailedFiltered); err != nil {
 			return err
 		}
 
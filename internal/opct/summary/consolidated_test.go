@@ -0,0 +1,27 @@
+package summary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConsolidatedSummaryBaselineAPIOptions(t *testing.T) {
+	cs, err := NewConsolidatedSummary(&ConsolidatedSummaryInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, NewDefaultBaselineAPIOptions(), cs.BaselineAPIOptions)
+
+	for _, opts := range []*BaselineAPIOptions{
+		{Count: 5, MinFailureShare: 0},
+		{Count: 5, MinFailureShare: 1.5},
+		{Count: 5, MinFailureShare: -0.1},
+		{Count: -1, MinFailureShare: 0.5},
+	} {
+		_, err := NewConsolidatedSummary(&ConsolidatedSummaryInput{BaselineAPIOptions: opts})
+		assert.Error(t, err, "count=%d share=%v", opts.Count, opts.MinFailureShare)
+	}
+
+	cs, err = NewConsolidatedSummary(&ConsolidatedSummaryInput{BaselineAPIOptions: &BaselineAPIOptions{Count: 3, MinFailureShare: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, cs.BaselineAPIOptions.Count)
+}
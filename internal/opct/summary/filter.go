@@ -0,0 +1,215 @@
+package summary
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+)
+
+// Filter is a step of the failure filter pipeline. Filters receive the failures
+// kept by the previous step for a plugin, returning the failures which must move
+// forward in the pipeline, and the failures excluded by the filter.
+// New filters must be added to the registry with RegisterFilter, and referenced
+// by ID in the pipeline definition (see DefaultFilterPipeline).
+type Filter interface {
+	// ID is the unique identifier of the filter in the registry and pipeline.
+	ID() string
+
+	// Description is a short description of the filter to be shown in the report.
+	Description() string
+
+	// Setup is called once before the filter is applied to the plugins, allowing
+	// the filter to load external data required for the processing.
+	Setup(cs *ConsolidatedSummary) error
+
+	// Apply filters the failures of a plugin. Filters may record the reason and the
+	// evidence of the decision to each failure with recordDecision, otherwise a default
+	// decision is recorded by the pipeline.
+	Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) (kept []string, excluded []string, err error)
+}
+
+// FilterPipelineStage defines a step in the filter pipeline.
+type FilterPipelineStage struct {
+	// FilterID is the ID of the filter in the registry.
+	FilterID string
+
+	// Plugins is the list of plugins the filter is applied to. Failures
+	// from plugins not in the list are copied to the next stage.
+	Plugins []string
+}
+
+// FilterPipeline is the ordered list of stages applied to the failures.
+type FilterPipeline []FilterPipelineStage
+
+// DefaultFilterPipeline is the default pipeline applied to the failures (order matters).
+var DefaultFilterPipeline = FilterPipeline{
+	{FilterID: plugin.FilterNameSuiteOnly, Plugins: plugin.ConformancePlugins},
+	{FilterID: plugin.FilterNameKF, Plugins: plugin.ConformancePlugins},
+	{FilterID: plugin.FilterNameReplay, Plugins: []string{
+		plugin.PluginNameKubernetesConformance,
+		plugin.PluginNameOpenShiftConformance,
+	}},
+	{FilterID: plugin.FilterNameBaseline, Plugins: plugin.ConformancePlugins},
+	{FilterID: plugin.FilterNameFlaky, Plugins: []string{
+		plugin.PluginNameKubernetesConformance,
+		plugin.PluginNameOpenShiftConformance,
+	}},
+	{FilterID: plugin.FilterNameBaselineAPI, Plugins: []string{
+		plugin.PluginNameOpenShiftUpgrade,
+		plugin.PluginNameKubernetesConformance,
+		plugin.PluginNameOpenShiftConformance,
+	}},
+}
+
+// Plugins returns the plugins processed by the pipeline: the union of the plugins
+// of each stage, in the order they are referenced.
+func (fp FilterPipeline) Plugins() []string {
+	lists := [][]string{}
+	for _, stage := range fp {
+		lists = append(lists, stage.Plugins)
+	}
+	return unionPlugins(lists...)
+}
+
+// unionPlugins returns the plugins of the lists without duplicates, in the order
+// they are referenced.
+func unionPlugins(lists ...[]string) []string {
+	plugins := []string{}
+	seen := map[string]struct{}{}
+	for _, list := range lists {
+		for _, p := range list {
+			if _, ok := seen[p]; ok {
+				continue
+			}
+			seen[p] = struct{}{}
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins
+}
+
+// appliesTo checks if the stage must be applied to the plugin.
+func (st *FilterPipelineStage) appliesTo(pluginName string) bool {
+	for _, p := range st.Plugins {
+		if p == pluginName {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	filterRegistryLock sync.RWMutex
+	filterRegistry     = map[string]Filter{}
+)
+
+// RegisterFilter adds a filter to the registry, returning error when
+// a filter with the same ID is already registered.
+func RegisterFilter(f Filter) error {
+	filterRegistryLock.Lock()
+	defer filterRegistryLock.Unlock()
+	if _, ok := filterRegistry[f.ID()]; ok {
+		return fmt.Errorf("filter %q is already registered", f.ID())
+	}
+	filterRegistry[f.ID()] = f
+	return nil
+}
+
+// GetFilter returns a filter from the registry by ID.
+func GetFilter(id string) (Filter, error) {
+	filterRegistryLock.RLock()
+	defer filterRegistryLock.RUnlock()
+	f, ok := filterRegistry[id]
+	if !ok {
+		return nil, fmt.Errorf("filter %q not found in the registry", id)
+	}
+	return f, nil
+}
+
+// applyFilterPipeline runs each stage of the pipeline for the provider plugins referenced
+// by the pipeline, stages not applied to a plugin copy the failures to the next stage.
+func (cs *ConsolidatedSummary) applyFilterPipeline() error {
+	for idx, stage := range cs.Pipeline {
+		f, err := GetFilter(stage.FilterID)
+		if err != nil {
+			return fmt.Errorf("error while building filter pipeline: %w", err)
+		}
+		log.Debugf("Processing results/Applying filters/%d/%s", idx+1, f.ID())
+		cs.Timers.Set(fmt.Sprintf("cs-process/filter%d-%s", idx+1, f.ID()))
+
+		if err := f.Setup(cs); err != nil {
+			return fmt.Errorf("error while setting up filter %d (%s): %w", idx+1, f.ID(), err)
+		}
+		for _, pluginName := range cs.Pipeline.Plugins() {
+			if err := cs.applyFilterForPlugin(f, &stage, pluginName); err != nil {
+				return fmt.Errorf("error while processing filter %d (%s): %w", idx+1, f.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyFilterForPlugin applies the filter to the failures of the previous stage,
+// saving the results as a new stage in the plugin summary.
+func (cs *ConsolidatedSummary) applyFilterForPlugin(f Filter, stage *FilterPipelineStage, pluginName string) error {
+	ps := cs.GetProvider().GetOpenShift().GetResultByName(pluginName)
+	if ps == nil {
+		log.Debugf("Filter (%s): skipping plugin %s, no results", f.ID(), pluginName)
+		return nil
+	}
+
+	failures := ps.GetPreviousFailuresByFilterID(f.ID())
+	if !stage.appliesTo(pluginName) {
+		ps.SetFailuresByFilterID(f.ID(), failures, nil)
+		ps.GetFilterStage(f.ID()).Description = f.Description()
+		ps.GetFilterStage(f.ID()).Skipped = true
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Filter is not applied to the plugin", nil)
+		}
+		return nil
+	}
+	for _, v := range failures {
+		if _, ok := ps.Tests[v]; ok {
+			ps.Tests[v].State = f.ID()
+		}
+	}
+
+	kept, excluded, err := f.Apply(cs, ps, failures)
+	if err != nil {
+		return err
+	}
+	sort.Strings(kept)
+	ps.SetFailuresByFilterID(f.ID(), kept, excluded)
+	ps.GetFilterStage(f.ID()).Description = f.Description()
+
+	// default decisions for failures not explained by the filter.
+	for _, v := range kept {
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Not matched by the filter", nil)
+	}
+	for _, v := range excluded {
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionExcluded, f.Description(), nil)
+	}
+
+	log.Debugf("Filter (%s) results: plugin=%s in=filter(%d) out=filter(%d) filterExcluded(%d)",
+		f.ID(), pluginName, len(failures), len(kept), len(excluded))
+	return nil
+}
+
+// recordDecision appends the filter decision to the trace of the test, when the
+// test exists in the plugin and the filter has not recorded a decision yet.
+func recordDecision(ps *plugin.OPCTPluginSummary, name, filterID, decision, reason string, evidence map[string]string) {
+	test, ok := ps.Tests[name]
+	if !ok || test.GetDecision(filterID) != nil {
+		return
+	}
+	test.AddDecision(&plugin.FilterDecision{
+		Filter:   filterID,
+		Decision: decision,
+		Reason:   reason,
+		Evidence: evidence,
+	})
+}
@@ -0,0 +1,136 @@
+package summary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/metrics"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+)
+
+// filterTestPrefix is a fake filter excluding failures by prefix.
+type filterTestPrefix struct {
+	id     string
+	prefix string
+}
+
+func (f *filterTestPrefix) ID() string                          { return f.id }
+func (f *filterTestPrefix) Description() string                 { return "test filter " + f.id }
+func (f *filterTestPrefix) Setup(cs *ConsolidatedSummary) error { return nil }
+func (f *filterTestPrefix) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	var kept, excluded []string
+	for _, v := range failures {
+		if strings.HasPrefix(v, f.prefix) {
+			excluded = append(excluded, v)
+			continue
+		}
+		kept = append(kept, v)
+	}
+	return kept, excluded, nil
+}
+
+func TestRegisterFilter(t *testing.T) {
+	for _, id := range []string{
+		plugin.FilterNameSuiteOnly,
+		plugin.FilterNameKF,
+		plugin.FilterNameReplay,
+		plugin.FilterNameBaseline,
+		plugin.FilterNameFlaky,
+		plugin.FilterNameBaselineAPI,
+	} {
+		f, err := GetFilter(id)
+		assert.NoError(t, err)
+		assert.Equal(t, id, f.ID())
+	}
+
+	_, err := GetFilter("not-found")
+	assert.Error(t, err)
+
+	err = RegisterFilter(&filterTestPrefix{id: plugin.FilterNameKF})
+	assert.Error(t, err, "duplicated filter must not be registered")
+}
+
+func TestApplyFilterPipeline(t *testing.T) {
+	assert.NoError(t, RegisterFilter(&filterTestPrefix{id: "test-a", prefix: "a"}))
+	assert.NoError(t, RegisterFilter(&filterTestPrefix{id: "test-b", prefix: "b"}))
+
+	cs, err := NewConsolidatedSummary(&ConsolidatedSummaryInput{
+		Timers: metrics.NewTimers(),
+		FilterPipeline: FilterPipeline{
+			{FilterID: "test-a", Plugins: []string{plugin.PluginNameKubernetesConformance, plugin.PluginNameOpenShiftConformance}},
+			{FilterID: "test-b", Plugins: []string{plugin.PluginNameOpenShiftConformance}},
+		},
+	})
+	assert.NoError(t, err)
+	failures := []string{"c1", "b1", "a1", "a2"}
+	for _, name := range []string{plugin.PluginNameKubernetesConformance, plugin.PluginNameOpenShiftConformance} {
+		tests := plugin.Tests{}
+		for _, v := range failures {
+			tests[v] = &plugin.TestItem{Name: v, Status: "failed"}
+		}
+		assert.NoError(t, cs.GetProvider().GetOpenShift().SetPluginResult(&plugin.OPCTPluginSummary{
+			Name:       name,
+			FailedList: failures,
+			Tests:      tests,
+		}))
+	}
+
+	assert.NoError(t, cs.applyFilterPipeline())
+	assert.NoError(t, cs.applyFilterCopyPipeline(plugin.FilterNameFinalCopy))
+
+	k8s := cs.GetProvider().GetOpenShift().GetResultK8SValidated()
+	assert.Len(t, k8s.Filters, 2)
+	assert.Equal(t, []string{"b1", "c1"}, k8s.GetFilterStage("test-a").Failures)
+	assert.Equal(t, []string{"a1", "a2"}, k8s.GetFilterStage("test-a").Excluded)
+	assert.True(t, k8s.GetFilterStage("test-b").Skipped)
+	assert.Equal(t, []string{"b1", "c1"}, k8s.FailedFiltered)
+
+	ocp := cs.GetProvider().GetOpenShift().GetResultOCPValidated()
+	assert.Equal(t, []string{"test-a", "test-b"}, []string{ocp.Filters[0].ID, ocp.Filters[1].ID})
+	assert.Equal(t, []string{"b1"}, ocp.GetFilterStage("test-b").Excluded)
+	assert.Equal(t, []string{"c1"}, ocp.FailedFiltered)
+
+	// decision trace
+	assert.Len(t, ocp.Tests["a1"].Decisions, 1)
+	assert.Equal(t, plugin.FilterDecisionExcluded, ocp.Tests["a1"].GetDecision("test-a").Decision)
+	assert.Equal(t, "test filter test-a", ocp.Tests["a1"].GetDecision("test-a").Reason)
+	assert.Len(t, ocp.Tests["b1"].Decisions, 2)
+	assert.Equal(t, plugin.FilterDecisionKept, ocp.Tests["b1"].Decisions[0].Decision)
+	assert.Equal(t, plugin.FilterDecisionExcluded, ocp.Tests["b1"].Decisions[1].Decision)
+	assert.Len(t, k8s.Tests["c1"].Decisions, 2)
+	assert.Equal(t, plugin.FilterDecisionKept, k8s.Tests["c1"].GetDecision("test-b").Decision)
+}
+
+func TestApplyFilterPipelineStagePlugins(t *testing.T) {
+	assert.NoError(t, RegisterFilter(&filterTestPrefix{id: "test-collector", prefix: "a"}))
+
+	pipeline := FilterPipeline{
+		{FilterID: "test-collector", Plugins: []string{plugin.PluginNameArtifactsCollector, plugin.PluginNameOpenShiftConformance}},
+	}
+	assert.Equal(t, []string{plugin.PluginNameArtifactsCollector, plugin.PluginNameOpenShiftConformance}, pipeline.Plugins())
+
+	cs, err := NewConsolidatedSummary(&ConsolidatedSummaryInput{
+		Timers:         metrics.NewTimers(),
+		FilterPipeline: pipeline,
+	})
+	assert.NoError(t, err)
+	failures := []string{"b1", "a1"}
+	tests := plugin.Tests{}
+	for _, v := range failures {
+		tests[v] = &plugin.TestItem{Name: v, Status: "failed"}
+	}
+	assert.NoError(t, cs.GetProvider().GetOpenShift().SetPluginResult(&plugin.OPCTPluginSummary{
+		Name:       plugin.PluginNameArtifactsCollector,
+		FailedList: failures,
+		Tests:      tests,
+	}))
+
+	assert.NoError(t, cs.applyFilterPipeline())
+	assert.NoError(t, cs.applyFilterCopyPipeline(plugin.FilterNameFinalCopy))
+
+	collector := cs.GetProvider().GetOpenShift().GetResultArtifactsCollector()
+	assert.Equal(t, []string{"a1"}, collector.GetFilterStage("test-collector").Excluded)
+	assert.Equal(t, []string{"b1"}, collector.FailedFiltered)
+}
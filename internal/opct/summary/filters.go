@@ -0,0 +1,472 @@
+package summary
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pkg/errors"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/ci/sippy"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+)
+
+// Built-in filters registered by default in the filter pipeline.
+func init() {
+	for _, f := range []Filter{
+		&filterSuiteOnly{},
+		&filterKnownFailures{},
+		&filterReplay{},
+		&filterBaseline{},
+		&filterFlaky{},
+		&filterBaselineAPI{},
+	} {
+		if err := RegisterFilter(f); err != nil {
+			log.Fatalf("unable to register filter: %v", err)
+		}
+	}
+}
+
+// pluginID returns the current plugin name for the plugin summary, translating
+// the names used by old versions of the plugins.
+func pluginID(ps *plugin.OPCTPluginSummary) string {
+	switch ps.Name {
+	case plugin.PluginOldNameKubernetesConformance:
+		return plugin.PluginNameKubernetesConformance
+	case plugin.PluginOldNameOpenShiftConformance:
+		return plugin.PluginNameOpenShiftConformance
+	}
+	return ps.Name
+}
+
+// excludeByHash splits the failures by the existence in the hash, returning
+// the list of failures not found (kept), and found (excluded).
+func excludeByHash(failures []string, hash map[string]struct{}) (kept []string, excluded []string) {
+	for _, v := range failures {
+		if _, ok := hash[v]; !ok {
+			kept = append(kept, v)
+			continue
+		}
+		excluded = append(excluded, v)
+	}
+	return kept, excluded
+}
+
+// filterSuiteOnly calculates the **intersection** of the plugin failures and the
+// tests in the respective suite.
+type filterSuiteOnly struct{}
+
+func (f *filterSuiteOnly) ID() string { return plugin.FilterNameSuiteOnly }
+
+func (f *filterSuiteOnly) Description() string {
+	return "Failures included in the suite (tests list) of the plugin"
+}
+
+func (f *filterSuiteOnly) Setup(cs *ConsolidatedSummary) error { return nil }
+
+func (f *filterSuiteOnly) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	var suite *OpenshiftTestsSuite
+	switch pluginID(ps) {
+	case plugin.PluginNameKubernetesConformance:
+		suite = cs.GetProvider().GetSuites().KubernetesConformance
+	case plugin.PluginNameOpenShiftConformance:
+		suite = cs.GetProvider().GetSuites().OpenshiftConformance
+	}
+
+	// move on the pipeline when the suite is empty, or issues when collecting the counter.
+	if suite == nil || len(suite.Tests) == 0 {
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Suite list is not available for the plugin", nil)
+		}
+		return append([]string{}, failures...), nil, nil
+	}
+	hashSuite := make(map[string]struct{}, len(suite.Tests))
+	for _, v := range suite.Tests {
+		hashSuite[v] = struct{}{}
+	}
+
+	var kept, excluded []string
+	evidence := map[string]string{"suite": suite.Name}
+	for _, v := range failures {
+		if _, ok := hashSuite[v]; ok {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Test is part of the suite", evidence)
+			kept = append(kept, v)
+			continue
+		}
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionExcluded, "Test is not part of the suite", evidence)
+		excluded = append(excluded, v)
+	}
+	return kept, excluded, nil
+}
+
+// filterKnownFailures skip well known failures that are not relevant to the validation process.
+type filterKnownFailures struct{}
+
+func (f *filterKnownFailures) ID() string { return plugin.FilterNameKF }
+
+func (f *filterKnownFailures) Description() string {
+	return "Known failures not relevant to the validation process"
+}
+
+func (f *filterKnownFailures) Setup(cs *ConsolidatedSummary) error {
+	knownFailures := append([]*KnownFailure{}, defaultKnownFailures...)
+	if cs.KnownFailuresFile != "" {
+		kfs, err := LoadKnownFailuresFromFile(cs.KnownFailuresFile)
+		if err != nil {
+			return err
+		}
+		log.Infof("Loaded %d known failures from file %s", len(kfs), cs.KnownFailuresFile)
+		knownFailures = append(knownFailures, kfs...)
+	}
+
+	// Expired entries are not used in the pipeline, the user must review and renew it.
+	now := time.Now()
+	cs.Provider.TestSuiteKnownFailures = []*KnownFailure{}
+	cs.Provider.expiredKnownFailures = []*KnownFailure{}
+	for _, kf := range knownFailures {
+		if kf.IsExpired(now) {
+			log.Warnf("Known failure entry %s expired at %s, ignoring it (reason=%q bug=%q)", kf, kf.Expires, kf.Reason, kf.Bug)
+			cs.Provider.expiredKnownFailures = append(cs.Provider.expiredKnownFailures, kf)
+			continue
+		}
+		cs.Provider.TestSuiteKnownFailures = append(cs.Provider.TestSuiteKnownFailures, kf)
+	}
+	return nil
+}
+
+func (f *filterKnownFailures) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	version := ""
+	if cv, err := cs.GetProvider().GetOpenShift().GetClusterVersion(); err == nil {
+		version = cv.Desired
+	}
+	platformType := cs.GetProvider().GetOpenShift().GetInfrastructurePlatformType()
+
+	inScope := func(entries []*KnownFailure) []*KnownFailure {
+		res := []*KnownFailure{}
+		for _, kf := range entries {
+			if kf.InScope(version, platformType, pluginID(ps)) {
+				res = append(res, kf)
+			}
+		}
+		return res
+	}
+	knownFailures := inScope(cs.Provider.TestSuiteKnownFailures)
+	expired := inScope(cs.Provider.expiredKnownFailures)
+
+	var kept, excluded []string
+	for _, v := range failures {
+		var matched *KnownFailure
+		for _, kf := range knownFailures {
+			if kf.Matches(v) {
+				log.Debugf("Filter (KF): plugin=%s test=%q matched known failure %s (reason=%q bug=%q)", ps.Name, v, kf, kf.Reason, kf.Bug)
+				matched = kf
+				break
+			}
+		}
+		if matched != nil {
+			evidence := map[string]string{"entry": matched.String()}
+			if matched.Bug != "" {
+				evidence["bug"] = matched.Bug
+			}
+			if matched.Expires != "" {
+				evidence["expires"] = matched.Expires
+			}
+			reason := "Matched known failure"
+			if matched.Reason != "" {
+				reason = fmt.Sprintf("Matched known failure: %s", matched.Reason)
+			}
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionExcluded, reason, evidence)
+			excluded = append(excluded, v)
+			continue
+		}
+		kept = append(kept, v)
+		for _, kf := range expired {
+			if kf.Matches(v) {
+				recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Matched expired known failure, review and renew the entry",
+					map[string]string{"entry": kf.String(), "expires": kf.Expires})
+				break
+			}
+		}
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "No known failure entry matched", nil)
+	}
+	return kept, excluded, nil
+}
+
+// filterReplay skip failures that pass in replay, which can be a
+// candidate for flake or false-positive failure.
+// Replay step re-runs the failured tests from conformance suites in serial mode,
+// to check if the test is passing in a second shot.
+type filterReplay struct{}
+
+func (f *filterReplay) ID() string { return plugin.FilterNameReplay }
+
+func (f *filterReplay) Description() string {
+	return "Failures passing in the replay step (second shot)"
+}
+
+func (f *filterReplay) Setup(cs *ConsolidatedSummary) error { return nil }
+
+func (f *filterReplay) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	replayPlugin := cs.GetProvider().GetOpenShift().GetResultConformanceReplay()
+	if replayPlugin == nil || len(replayPlugin.Tests) == 0 {
+		log.Debugf("skipping filter (Replay) for plugin: %s, no replay results", ps.Name)
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Replay results are not available", nil)
+		}
+		return append([]string{}, failures...), nil, nil
+	}
+
+	passedReplay := make(map[string]struct{}, len(replayPlugin.Tests))
+	replayStatus := make(map[string]string, len(replayPlugin.Tests))
+	for _, test := range replayPlugin.Tests {
+		replayStatus[test.Name] = test.Status
+		if test.Status == "passed" {
+			passedReplay[test.Name] = struct{}{}
+		}
+	}
+	kept, excluded := excludeByHash(failures, passedReplay)
+	for _, v := range excluded {
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionExcluded, "Test passed in the replay step",
+			map[string]string{"replayStatus": replayStatus[v]})
+	}
+	for _, v := range kept {
+		status, ok := replayStatus[v]
+		if !ok {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Test was not executed in the replay step", nil)
+			continue
+		}
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Test did not pass in the replay step",
+			map[string]string{"replayStatus": status})
+	}
+	log.Debugf("Filter (Replay): plugin=%s replay=pass(%d) total(%d)", ps.Name, len(passedReplay), len(replayPlugin.Tests))
+	return kept, excluded, nil
+}
+
+// filterBaseline calculates the **exclusion** of failures from the plugin
+// and the failures from the baseline archive (--diff|--baseline).
+type filterBaseline struct{}
+
+func (f *filterBaseline) ID() string { return plugin.FilterNameBaseline }
+
+func (f *filterBaseline) Description() string {
+	return "Failures found in the baseline archive (--diff)"
+}
+
+func (f *filterBaseline) Setup(cs *ConsolidatedSummary) error {
+	// DEPRECATION warning when used:
+	if cs.GetBaseline().HasValidResults() {
+		log.Warnf("Filter baseline (--diff|--baseline) is deprecated and will be removed soon, the filter BaselineAPI is replacing and automatically applied to the failure pipeline.")
+	}
+	return nil
+}
+
+func (f *filterBaseline) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	var e2eFailuresBaseline []string
+	if cs.GetBaseline().HasValidResults() {
+		if bps := cs.GetBaseline().GetOpenShift().GetResultByName(pluginID(ps)); bps != nil {
+			e2eFailuresBaseline = bps.FailedList
+		}
+	} else {
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Baseline archive is not set", nil)
+		}
+	}
+	hashBaseline := make(map[string]struct{}, len(e2eFailuresBaseline))
+	for _, v := range e2eFailuresBaseline {
+		hashBaseline[v] = struct{}{}
+	}
+	kept, excluded := excludeByHash(failures, hashBaseline)
+	evidence := map[string]string{"baseline": cs.GetBaseline().Archive}
+	for _, v := range excluded {
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionExcluded, "Test failed in the baseline archive", evidence)
+	}
+	for _, v := range kept {
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Test did not fail in the baseline archive", evidence)
+	}
+	return kept, excluded, nil
+}
+
+// flakeThresholdPerc is the flake percentage (CurrentFlakePerc) reported by Sippy
+// above which failures are considered flakes.
+const flakeThresholdPerc = 5.0
+
+// filterFlaky query the Sippy API looking for each failed test
+// on each plugin/suite, **excluding** tests reported as flake in OpenShift CI.
+type filterFlaky struct{}
+
+func (f *filterFlaky) ID() string { return plugin.FilterNameFlaky }
+
+func (f *filterFlaky) Description() string {
+	return "Failures reported as flake in OpenShift CI (Sippy)"
+}
+
+func (f *filterFlaky) Setup(cs *ConsolidatedSummary) error {
+	ver, err := cs.GetProvider().GetOpenShift().GetClusterVersionXY()
+	if err != nil {
+		return errors.Errorf("Error getting cluster version: %v", err)
+	}
+	cs.sippyAPI = sippy.NewSippyAPIWithOptions(ver, cs.SippyOptions)
+	return nil
+}
+
+func (f *filterFlaky) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	var kept, excluded []string
+	api := cs.sippyAPI
+	resps, err := api.QueryTestsBatch(cs.Context(), failures)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying flake data: %w", err)
+	}
+	unavailable := 0
+	for _, name := range failures {
+		resp, ok := resps[name]
+		if !ok || resp == nil {
+			unavailable += 1
+			recordDecision(ps, name, f.ID(), plugin.FilterDecisionKept, "Flake data is unavailable", nil)
+			kept = append(kept, name)
+			continue
+		}
+		// tests without results in CI are not flakes, moving to the pipeline.
+		if len(*resp) == 0 {
+			recordDecision(ps, name, f.ID(), plugin.FilterDecisionKept, "Test has no results in OpenShift CI", nil)
+			kept = append(kept, name)
+			continue
+		}
+		r := (*resp)[0]
+		if _, ok := ps.Tests[name]; ok {
+			ps.Tests[name].Flake = &r
+		} else {
+			ps.Tests[name] = &plugin.TestItem{
+				Name:  name,
+				Flake: &r,
+			}
+		}
+		// Applying flake filter by moving only non-flakes to the pipeline.
+		// The tests reporing lower than 5% of CurrentFlakePerc by Sippy are selected as non-flake.
+		// TODO: Review flake severity
+		evidence := map[string]string{
+			"flakePerc":  fmt.Sprintf("%.2f", r.CurrentFlakePerc),
+			"flakeCount": fmt.Sprintf("%d", r.CurrentFlakes),
+			"runs":       fmt.Sprintf("%d", r.CurrentRuns),
+			"threshold":  fmt.Sprintf("%.2f", flakeThresholdPerc),
+		}
+		if ps.Tests[name].Flake.CurrentFlakePerc <= flakeThresholdPerc {
+			recordDecision(ps, name, f.ID(), plugin.FilterDecisionKept, "Flake percentage in OpenShift CI is lower than the threshold", evidence)
+			kept = append(kept, name)
+			continue
+		}
+		recordDecision(ps, name, f.ID(), plugin.FilterDecisionExcluded, "Flake percentage in OpenShift CI is higher than the threshold", evidence)
+		excluded = append(excluded, name)
+	}
+	if unavailable > 0 {
+		log.Warnf("Filter (FlakeAPI): flake data is unavailable for %d of %d failures in plugin %s, those failures are kept in the pipeline. Use 'opct adm sippy import' to load flake data in disconnected environments.",
+			unavailable, len(failures), ps.Name)
+	}
+	if err := api.SaveCache(); err != nil {
+		log.Warnf("Filter (FlakeAPI): unable to save the Sippy cache: %v", err)
+	}
+	return kept, excluded, nil
+}
+
+// countBaselineFailures counts the baselines each test of the plugin failed, returning
+// the names of the baselines counted. Baselines which failures can't be parsed (old
+// format or partial summaries) are logged and not counted in the total.
+func countBaselineFailures(baselines []*baseline.BaselineData, pluginName string) (map[string]int, []string) {
+	failuresCount := make(map[string]int)
+	names := []string{}
+	for _, b := range baselines {
+		baselineFailures, err := b.GetPriorityFailuresFromPlugin(pluginName)
+		if err != nil {
+			log.Warnf("Filter (BaselineAPI): unable to read the failures of plugin %s from baseline %s, skipping: %v", pluginName, b.GetName(), err)
+			continue
+		}
+		names = append(names, b.GetName())
+		seen := make(map[string]struct{}, len(baselineFailures))
+		for _, v := range baselineFailures {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			failuresCount[v] += 1
+		}
+	}
+	return failuresCount, names
+}
+
+// filterBaselineAPI excludes failures reported in the baseline results of the
+// same release and platform, collected from OPCT CI and served by the OPCT API.
+// This filter is similar to the Baseline, but the list of failures is collected from
+// processed data (another OPCT execution), preventing false positives on the review pipeline.
+type filterBaselineAPI struct{}
+
+func (f *filterBaselineAPI) ID() string { return plugin.FilterNameBaselineAPI }
+
+func (f *filterBaselineAPI) Description() string {
+	return "Failures reported in the baseline results from OPCT CI (API)"
+}
+
+func (f *filterBaselineAPI) Setup(cs *ConsolidatedSummary) error {
+	if err := cs.loadBaselineFromAPI(); err != nil {
+		return fmt.Errorf("loading baseline results from API: %w", err)
+	}
+	return nil
+}
+
+func (f *filterBaselineAPI) Apply(cs *ConsolidatedSummary, ps *plugin.OPCTPluginSummary, failures []string) ([]string, []string, error) {
+	// feed the pipeline with the same tests when the filter is disabled.
+	if os.Getenv("OPCT_DISABLE_FILTER_BASELINE") == "1" {
+		log.Warn("Filter pipeline: Basline API is explicitly disabled by OPCT_DISABLE_FILTER_BASELINE, using previous filter to keep processing failures")
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Filter is disabled by OPCT_DISABLE_FILTER_BASELINE", nil)
+		}
+		return append([]string{}, failures...), nil, nil
+	}
+
+	opts := cs.BaselineAPIOptions
+	if opts == nil {
+		opts = NewDefaultBaselineAPIOptions()
+	}
+	var baselines []*baseline.BaselineData
+	if cs.BaselineAPI != nil {
+		baselines = cs.BaselineAPI.GetBuffers()
+	}
+	if len(baselines) == 0 {
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Baseline results are not available", nil)
+		}
+		return append([]string{}, failures...), nil, nil
+	}
+
+	failuresCount, names := countBaselineFailures(baselines, pluginID(ps))
+	if len(names) == 0 {
+		for _, v := range failures {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Baseline results are not available", nil)
+		}
+		return append([]string{}, failures...), nil, nil
+	}
+
+	var kept, excluded []string
+	for _, v := range failures {
+		freq := &plugin.BaselineFrequency{Failures: failuresCount[v], Total: len(names)}
+		if test, ok := ps.Tests[v]; ok {
+			test.Baseline = freq
+		}
+		evidence := map[string]string{
+			"baselines":       strings.Join(names, ","),
+			"failures":        freq.String(),
+			"minFailureShare": fmt.Sprintf("%.2f", opts.MinFailureShare),
+		}
+		if freq.Failures > 0 && freq.Share() >= opts.MinFailureShare {
+			recordDecision(ps, v, f.ID(), plugin.FilterDecisionExcluded, "Test failed in the minimum share of the baseline results from OPCT CI", evidence)
+			excluded = append(excluded, v)
+			continue
+		}
+		recordDecision(ps, v, f.ID(), plugin.FilterDecisionKept, "Test failed in less than the minimum share of the baseline results from OPCT CI", evidence)
+		kept = append(kept, v)
+	}
+	log.Debugf("Filter (BaselineAPI): plugin=%s baselines=(%d) inApi=(%d)", ps.Name, len(names), len(failuresCount))
+	return kept, excluded, nil
+}
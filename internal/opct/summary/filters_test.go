@@ -0,0 +1,33 @@
+package summary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+)
+
+func TestCountBaselineFailures(t *testing.T) {
+	summaries := map[string]string{
+		"4.15_None_a": `{"provider":{"plugins":{"p1":{"id":"p1","failedFiltered":[{"name":"t1"},{"name":"t2"},{"name":"t1"}]}}}}`,
+		"4.15_None_b": `{"provider":{"plugins":{"p1":{"id":"p1","failedPriority":[{"name":"t1"}]}}}}`,
+		"4.15_None_c": `{"provider":{}}`,
+		"4.15_None_d": `{}`,
+	}
+	baselines := []*baseline.BaselineData{}
+	for _, name := range []string{"4.15_None_a", "4.15_None_b", "4.15_None_c", "4.15_None_d"} {
+		bd := &baseline.BaselineData{}
+		bd.SetName(name)
+		bd.SetRawData([]byte(summaries[name]))
+		baselines = append(baselines, bd)
+	}
+
+	counts, names := countBaselineFailures(baselines, "p1")
+	assert.Equal(t, []string{"4.15_None_a", "4.15_None_b"}, names)
+	assert.Equal(t, map[string]int{"t1": 2, "t2": 1}, counts)
+
+	counts, names = countBaselineFailures(baselines[2:], "p1")
+	assert.Empty(t, names)
+	assert.Empty(t, counts)
+}
@@ -0,0 +1,178 @@
+package summary
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// KnownFailure is an entry of the known failures list, used to remove failures
+// already triaged from the filter pipeline.
+type KnownFailure struct {
+	// Name is the exact name of the test. Name or Regex must be set.
+	Name string `yaml:"name,omitempty"`
+
+	// Regex is the regular expression matching the test names.
+	Regex string `yaml:"regex,omitempty"`
+
+	// Versions limits the entry to the OpenShift versions, in the format X.Y or X.Y.Z.
+	Versions []string `yaml:"versions,omitempty"`
+
+	// PlatformTypes limits the entry to the infrastructure platform types. Example: AWS, External, None.
+	PlatformTypes []string `yaml:"platformTypes,omitempty"`
+
+	// Plugins limits the entry to the plugins. Example: 20-openshift-conformance-validated.
+	Plugins []string `yaml:"plugins,omitempty"`
+
+	// Reason is the justification to mark the test as known failure.
+	Reason string `yaml:"reason,omitempty"`
+
+	// Bug is the link to the bug tracking the failure.
+	Bug string `yaml:"bug,omitempty"`
+
+	// Expires is the date (YYYY-MM-DD or RFC3339) the entry stops matching the failures.
+	Expires string `yaml:"expires,omitempty"`
+
+	re        *regexp.Regexp
+	expiresAt time.Time
+}
+
+// KnownFailuresFile is the file format of the known failures file (--known-failures).
+type KnownFailuresFile struct {
+	KnownFailures []*KnownFailure `yaml:"knownFailures"`
+}
+
+// defaultKnownFailures are well known failures that are not relevant to the validation process.
+var defaultKnownFailures = []*KnownFailure{
+	{
+		// The test is not relevant to the validation process, and it's not a real failure
+		// since the k8s/conformance suite is executed correctly.
+		Name:   "[sig-arch] External binary usage",
+		Reason: "Not relevant to the validation process, the conformance suite is executed correctly.",
+	},
+	{
+		// The custom MCP is used in the OPCT topology to executed in-cluster validation. If MCP
+		// is not used, the test environment would be evicted when the dedicated node is drained.
+		Name:   "[sig-mco] Machine config pools complete upgrade",
+		Reason: "Not relevant to the validation process, the custom MCP is used in the OPCT topology.",
+	},
+}
+
+// LoadKnownFailuresFromFile reads and validates the known failures file.
+func LoadKnownFailuresFromFile(path string) ([]*KnownFailure, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read known failures file: %w", err)
+	}
+	kff := KnownFailuresFile{}
+	if err := yaml.UnmarshalStrict(data, &kff); err != nil {
+		return nil, fmt.Errorf("unable to parse known failures file %s: %w", path, err)
+	}
+	for idx, kf := range kff.KnownFailures {
+		if err := kf.validate(); err != nil {
+			return nil, fmt.Errorf("invalid known failure entry #%d in %s: %w", idx, path, err)
+		}
+	}
+	return kff.KnownFailures, nil
+}
+
+// validate checks the required fields, compiling the regex and parsing the expiry date.
+func (kf *KnownFailure) validate() error {
+	if kf.Name == "" && kf.Regex == "" {
+		return fmt.Errorf("one of 'name' or 'regex' must be set")
+	}
+	if kf.Name != "" && kf.Regex != "" {
+		return fmt.Errorf("only one of 'name' or 'regex' must be set")
+	}
+	if kf.Reason == "" && kf.Bug == "" {
+		return fmt.Errorf("one of 'reason' or 'bug' must be set")
+	}
+	if kf.Regex != "" {
+		re, err := regexp.Compile(kf.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", kf.Regex, err)
+		}
+		kf.re = re
+	}
+	if kf.Expires != "" {
+		// date-only entries are valid until the end of the day.
+		t, err := time.Parse("2006-01-02", kf.Expires)
+		if err == nil {
+			t = t.Add(24 * time.Hour)
+		} else {
+			t, err = time.Parse(time.RFC3339, kf.Expires)
+			if err != nil {
+				return fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD or RFC3339", kf.Expires)
+			}
+		}
+		kf.expiresAt = t
+	}
+	return nil
+}
+
+// String returns the identifier of the entry.
+func (kf *KnownFailure) String() string {
+	if kf.Regex != "" {
+		return fmt.Sprintf("regex=%q", kf.Regex)
+	}
+	return fmt.Sprintf("name=%q", kf.Name)
+}
+
+// IsExpired checks if the entry has expired in a given time.
+func (kf *KnownFailure) IsExpired(now time.Time) bool {
+	return !kf.expiresAt.IsZero() && !now.Before(kf.expiresAt)
+}
+
+// InScope checks if the entry applies to the cluster version, platform type and plugin.
+// Empty scope attributes match everything.
+func (kf *KnownFailure) InScope(version, platformType, pluginName string) bool {
+	if len(kf.Versions) > 0 {
+		found := false
+		for _, v := range kf.Versions {
+			if v == version || strings.HasPrefix(version, v+".") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(kf.PlatformTypes) > 0 {
+		found := false
+		for _, p := range kf.PlatformTypes {
+			if strings.EqualFold(p, platformType) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(kf.Plugins) > 0 {
+		found := false
+		for _, p := range kf.Plugins {
+			if p == pluginName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches checks if the test name matches the entry.
+func (kf *KnownFailure) Matches(name string) bool {
+	if kf.re != nil {
+		return kf.re.MatchString(name)
+	}
+	return kf.Name == name
+}
@@ -0,0 +1,134 @@
+package summary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+)
+
+func TestLoadKnownFailuresFromFile(t *testing.T) {
+	tcs := []struct {
+		name    string
+		content string
+		wantErr bool
+		want    int
+	}{
+		{
+			name: "valid entries",
+			content: `knownFailures:
+- name: "[sig-a] test A"
+  reason: "triaged"
+- regex: '^\[sig-b\] .*'
+  bug: https://issues.redhat.com/browse/OCPBUGS-1
+  expires: "2024-01-02"
+`,
+			want: 2,
+		},
+		{
+			name:    "missing name and regex",
+			content: "knownFailures:\n- reason: triaged\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing reason and bug",
+			content: "knownFailures:\n- name: test\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			content: "knownFailures:\n- regex: '[a-'\n  reason: triaged\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid expiry",
+			content: "knownFailures:\n- name: test\n  reason: triaged\n  expires: tomorrow\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "knownFailures:\n- name: test\n  reason: triaged\n  owner: me\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "kf.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.content), 0644))
+			got, err := LoadKnownFailuresFromFile(path)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, tc.want)
+		})
+	}
+}
+
+func TestKnownFailureMatch(t *testing.T) {
+	kfRegex := &KnownFailure{
+		Regex:         `^\[sig-storage\] .*`,
+		Reason:        "triaged",
+		Versions:      []string{"4.15"},
+		PlatformTypes: []string{"External"},
+		Plugins:       []string{"20-openshift-conformance-validated"},
+		Expires:       "2024-06-01",
+	}
+	assert.NoError(t, kfRegex.validate())
+
+	assert.True(t, kfRegex.Matches("[sig-storage] CSI test"))
+	assert.False(t, kfRegex.Matches("[sig-network] test"))
+
+	assert.True(t, kfRegex.InScope("4.15.3", "external", "20-openshift-conformance-validated"))
+	assert.True(t, kfRegex.InScope("4.15", "External", "20-openshift-conformance-validated"))
+	assert.False(t, kfRegex.InScope("4.150.1", "External", "20-openshift-conformance-validated"))
+	assert.False(t, kfRegex.InScope("4.16.0", "External", "20-openshift-conformance-validated"))
+	assert.False(t, kfRegex.InScope("4.15.3", "AWS", "20-openshift-conformance-validated"))
+	assert.False(t, kfRegex.InScope("4.15.3", "External", "10-openshift-kube-conformance"))
+
+	assert.False(t, kfRegex.IsExpired(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, kfRegex.IsExpired(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, kfRegex.IsExpired(time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)))
+
+	kfName := &KnownFailure{Name: "[sig-a] test A", Bug: "https://issues.redhat.com/browse/OCPBUGS-1"}
+	assert.NoError(t, kfName.validate())
+	assert.True(t, kfName.Matches("[sig-a] test A"))
+	assert.False(t, kfName.Matches("[sig-a] test A and more"))
+	assert.True(t, kfName.InScope("4.16.0", "AWS", "any"))
+	assert.False(t, kfName.IsExpired(time.Now()))
+}
+
+func TestFilterKnownFailuresExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known-failures.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`knownFailures:
+- name: "[sig-a] test A"
+  reason: "triaged"
+  expires: "2999-01-01"
+- name: "[sig-b] test B"
+  reason: "stale"
+  expires: "2020-01-01"
+`), 0644))
+	cs, err := NewConsolidatedSummary(&ConsolidatedSummaryInput{KnownFailuresFile: path})
+	assert.NoError(t, err)
+
+	f := &filterKnownFailures{}
+	assert.NoError(t, f.Setup(cs))
+	failures := []string{"[sig-a] test A", "[sig-b] test B"}
+	ps := &plugin.OPCTPluginSummary{Name: plugin.PluginNameOpenShiftConformance, Tests: plugin.Tests{}}
+	for _, name := range failures {
+		ps.Tests[name] = &plugin.TestItem{Name: name, Status: "failed"}
+	}
+	kept, excluded, err := f.Apply(cs, ps, failures)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[sig-a] test A"}, excluded)
+	assert.Equal(t, []string{"[sig-b] test B"}, kept, "expired entries must not hide failures")
+
+	decision := ps.Tests["[sig-b] test B"].GetDecision(plugin.FilterNameKF)
+	assert.Equal(t, plugin.FilterDecisionKept, decision.Decision)
+	assert.Equal(t, "2020-01-01", decision.Evidence["expires"])
+}
@@ -345,3 +345,21 @@ func (os *OpenShiftSummary) GetResultConformanceReplay() *plugin.OPCTPluginSumma
 	}
 	return os.PluginResultConformanceReplay
 }
+
+// GetResultByName returns the plugin results by plugin name, or nil when the
+// plugin is unknown.
+func (os *OpenShiftSummary) GetResultByName(pluginName string) *plugin.OPCTPluginSummary {
+	switch pluginName {
+	case plugin.PluginNameKubernetesConformance:
+		return os.GetResultK8SValidated()
+	case plugin.PluginNameOpenShiftConformance:
+		return os.GetResultOCPValidated()
+	case plugin.PluginNameOpenShiftUpgrade:
+		return os.GetResultConformanceUpgrade()
+	case plugin.PluginNameConformanceReplay:
+		return os.GetResultConformanceReplay()
+	case plugin.PluginNameArtifactsCollector:
+		return os.GetResultArtifactsCollector()
+	}
+	return nil
+}
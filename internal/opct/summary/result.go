@@ -54,8 +54,12 @@ type ResultSummary struct {
 	// Metrics stores the extracted items from must-gather metrics.
 	Metrics *mustgathermetrics.MustGatherMetrics
 
-	// Plugin Know failures
-	TestSuiteKnownFailures []string
+	// Plugin Know failures (active entries, expired entries are removed).
+	TestSuiteKnownFailures []*KnownFailure
+
+	// expiredKnownFailures are the expired entries, never applied, reported in the
+	// decision of the failures they would match.
+	expiredKnownFailures []*KnownFailure
 
 	// BaselineAPI holds the data fetched from the baseline API.
 	BaselineAPI string
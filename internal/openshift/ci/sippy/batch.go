@@ -0,0 +1,116 @@
+package sippy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// QueryTestsBatch queries the results of a list of tests by name, returning the
+// result items indexed by test name. Tests without data available (API errors
+// without cache, or not cached in offline mode) are not included in the result.
+//
+// The cached entries are used with the same rules of QueryTests. The remaining
+// tests are grouped in batches (filter items linked by the 'or' operator), and
+// queried by a bounded pool of workers limited by the request rate.
+// The query stops when the context is cancelled, returning the context error.
+func (a *SippyAPI) QueryTestsBatch(ctx context.Context, names []string) (map[string]*SippyTestsRequestOutput, error) {
+	results := make(map[string]*SippyTestsRequestOutput, len(names))
+	expired := make(map[string]*CacheEntry)
+	pending := []string{}
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		if a.cache != nil {
+			entry, fresh := a.cache.Get(a.ocpVersion, name)
+			if entry != nil && (fresh || a.offline) {
+				a.addStats(entrySource(entry), !fresh)
+				results[name] = &entry.Response
+				continue
+			}
+			if entry != nil {
+				expired[name] = entry
+			}
+		}
+		if a.offline {
+			log.Debugf("Sippy API: test not found in the cache (offline mode): %s", name)
+			a.addStats(DataSourceUnavailable, false)
+			continue
+		}
+		pending = append(pending, name)
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	var lock sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(a.concurrency)
+	for _, batch := range splitBatches(pending, a.batchSize) {
+		batch := batch
+		g.Go(func() error {
+			resp, err := a.queryTestsAPI(gctx, newTestsFilter(batch))
+			if err != nil && gctx.Err() != nil {
+				return gctx.Err()
+			}
+			byName := make(map[string]SippyTestsRequestOutput, len(batch))
+			if resp != nil {
+				for _, r := range *resp {
+					byName[r.Name] = append(byName[r.Name], r)
+				}
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			for _, name := range batch {
+				if err != nil {
+					if entry, ok := expired[name]; ok {
+						log.Warnf("Sippy API: using expired cache entry from %s for test %q: %v", entry.UpdatedAt.Format(time.RFC3339), name, err)
+						a.addStats(entrySource(entry), true)
+						results[name] = &entry.Response
+						continue
+					}
+					log.Debugf("Sippy API: unable to query test %q: %v", name, err)
+					a.addStats(DataSourceUnavailable, false)
+					continue
+				}
+				out := byName[name]
+				if out == nil {
+					out = SippyTestsRequestOutput{}
+				}
+				if a.cache != nil {
+					a.cache.Set(a.ocpVersion, name, out)
+				}
+				a.addStats(DataSourceAPI, false)
+				results[name] = &out
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// splitBatches splits the list in batches with the maximum size.
+func splitBatches(items []string, size int) [][]string {
+	if size <= 0 {
+		size = 1
+	}
+	batches := [][]string{}
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		batches = append(batches, items[start:end])
+	}
+	return batches
+}
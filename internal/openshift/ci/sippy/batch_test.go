@@ -0,0 +1,105 @@
+package sippy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestSplitBatches(t *testing.T) {
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, splitBatches([]string{"a", "b", "c"}, 2))
+	assert.Equal(t, [][]string{{"a"}, {"b"}}, splitBatches([]string{"a", "b"}, 0))
+	assert.Equal(t, [][]string{}, splitBatches([]string{}, 2))
+}
+
+func TestNewTestsFilter(t *testing.T) {
+	assert.Equal(t, "", newTestsFilter([]string{"a"}).LinkOperator)
+	filter := newTestsFilter([]string{"a", "b"})
+	assert.Equal(t, "or", filter.LinkOperator)
+	assert.Len(t, filter.Items, 2)
+}
+
+func TestQueryTestsBatch(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		filter := SippyTestsRequestFilter{}
+		assert.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter))
+		resp := SippyTestsRequestOutput{}
+		for _, item := range filter.Items {
+			// test-c has no results in CI.
+			if item.Value == "test-c" {
+				continue
+			}
+			resp = append(resp, SippyTestsResponse{Name: item.Value, CurrentFlakePerc: 10.0})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	api := NewSippyAPIWithOptions("4.15", &Options{CacheDir: t.TempDir(), BatchSize: 2, RequestRate: 100})
+	api.baseURL = srv.URL
+	names := []string{"test-a", "test-b", "test-c", "test-a"}
+	results, err := api.QueryTestsBatch(context.Background(), names)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Len(t, results, 3)
+	assert.Equal(t, "test-b", (*results["test-b"])[0].Name)
+	assert.Empty(t, *results["test-c"])
+	assert.Equal(t, 3, api.GetStats().API)
+
+	// cached entries must not reach the API.
+	results, err = api.QueryTestsBatch(context.Background(), names)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Len(t, results, 3)
+	assert.Equal(t, 3, api.GetStats().Cache)
+}
+
+func TestQueryTestsBatchCancel(t *testing.T) {
+	api := NewSippyAPIWithOptions("4.15", &Options{DisableCache: true})
+	api.baseURL = "http://127.0.0.1:0"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := api.QueryTestsBatch(ctx, []string{"test-a"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestQueryTestsRetries(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// zero retries must not fall back to the default.
+	retries := 0
+	api := NewSippyAPIWithOptions("4.15", &Options{DisableCache: true, MaxRetries: &retries})
+	api.baseURL = srv.URL
+	_, err := api.QueryTests(&SippyTestsRequestInput{TestName: "test-a"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// every attempt waits for the limiter: 3 requests at 10 req/s (burst 1) take >= 200ms.
+	client := newHTTPClient(0, rate.NewLimiter(rate.Limit(10), 1))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(srv.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
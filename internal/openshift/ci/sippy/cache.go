@@ -0,0 +1,273 @@
+package sippy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCacheTTL is the time a cached response is considered fresh.
+	DefaultCacheTTL = 24 * time.Hour
+
+	// snapshotVersion is the format version of the exported snapshot.
+	snapshotVersion = "v1"
+)
+
+// releasePattern is the format of the releases (X.Y) stored in the cache, used as file names.
+var releasePattern = regexp.MustCompile(`^\d+\.\d+$`)
+
+// validateRelease checks the release is X.Y, rejecting names escaping the cache directory.
+func validateRelease(release string) error {
+	if !releasePattern.MatchString(release) {
+		return fmt.Errorf("invalid release %q, expected X.Y", release)
+	}
+	return nil
+}
+
+// Data sources of flake data.
+const (
+	DataSourceAPI         = "api"
+	DataSourceCache       = "cache"
+	DataSourceSnapshot    = "snapshot"
+	DataSourceUnavailable = "unavailable"
+)
+
+// CacheEntry is a cached response of a test query.
+type CacheEntry struct {
+	Response  SippyTestsRequestOutput `json:"response"`
+	UpdatedAt time.Time               `json:"updatedAt"`
+	// Source is the origin of the entry: api or snapshot (imported).
+	Source string `json:"source"`
+}
+
+// CacheRelease holds the cached entries for a release, indexed by test name.
+type CacheRelease struct {
+	Release string                 `json:"release"`
+	Tests   map[string]*CacheEntry `json:"tests"`
+}
+
+// Snapshot is the portable format used to export and import the cache,
+// carrying the flake data to disconnected environments.
+type Snapshot struct {
+	Version   string          `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Releases  []*CacheRelease `json:"releases"`
+}
+
+// Cache is a persistent on-disk cache of Sippy responses, stored as one
+// JSON file by release in the cache directory.
+type Cache struct {
+	dir string
+	ttl time.Duration
+
+	lock     sync.Mutex
+	releases map[string]*CacheRelease
+	changed  map[string]bool
+}
+
+// DefaultCacheDir returns the default cache directory: <user cache dir>/opct/sippy.
+func DefaultCacheDir() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "opct", "sippy")
+}
+
+// NewCache creates a cache instance for the directory, using the default
+// directory and TTL when not set.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Cache{
+		dir:      dir,
+		ttl:      ttl,
+		releases: make(map[string]*CacheRelease),
+		changed:  make(map[string]bool),
+	}
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) releaseFile(release string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%s.json", release))
+}
+
+// load reads the release file from disk when it was not loaded yet. Invalid
+// releases are not read, returning an empty release not stored in the cache.
+// The caller must hold the lock.
+func (c *Cache) load(release string) *CacheRelease {
+	if cr, ok := c.releases[release]; ok {
+		return cr
+	}
+	cr := &CacheRelease{Release: release, Tests: make(map[string]*CacheEntry)}
+	if err := validateRelease(release); err != nil {
+		log.Warnf("Sippy cache: ignoring release: %v", err)
+		return cr
+	}
+	data, err := os.ReadFile(c.releaseFile(release))
+	if err == nil {
+		if err := json.Unmarshal(data, cr); err != nil {
+			log.Warnf("Sippy cache: ignoring invalid cache file %s: %v", c.releaseFile(release), err)
+			cr = &CacheRelease{Release: release, Tests: make(map[string]*CacheEntry)}
+		}
+		if cr.Tests == nil {
+			cr.Tests = make(map[string]*CacheEntry)
+		}
+	} else if !os.IsNotExist(err) {
+		log.Warnf("Sippy cache: unable to read cache file: %v", err)
+	}
+	c.releases[release] = cr
+	return cr
+}
+
+// Get returns the cached entry for the test, and if the entry is fresh (not expired by TTL).
+func (c *Cache) Get(release, testName string) (entry *CacheEntry, fresh bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.load(release).Tests[testName]
+	if !ok {
+		return nil, false
+	}
+	return entry, time.Since(entry.UpdatedAt) <= c.ttl
+}
+
+// Set stores the response for the test.
+func (c *Cache) Set(release, testName string, resp SippyTestsRequestOutput) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.load(release).Tests[testName] = &CacheEntry{
+		Response:  resp,
+		UpdatedAt: time.Now(),
+		Source:    DataSourceAPI,
+	}
+	if validateRelease(release) == nil {
+		c.changed[release] = true
+	}
+}
+
+// Save writes the changed releases to disk.
+func (c *Cache) Save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.changed) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("unable to create cache directory: %w", err)
+	}
+	for release := range c.changed {
+		data, err := json.Marshal(c.releases[release])
+		if err != nil {
+			return fmt.Errorf("unable to encode cache for release %s: %w", release, err)
+		}
+		if err := os.WriteFile(c.releaseFile(release), data, 0644); err != nil {
+			return fmt.Errorf("unable to write cache for release %s: %w", release, err)
+		}
+		delete(c.changed, release)
+	}
+	return nil
+}
+
+// Releases returns the list of releases stored in the cache directory.
+func (c *Cache) Releases() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	releases := []string{}
+	for _, f := range files {
+		release := strings.TrimSuffix(filepath.Base(f), ".json")
+		if validateRelease(release) != nil {
+			continue
+		}
+		releases = append(releases, release)
+	}
+	sort.Strings(releases)
+	return releases, nil
+}
+
+// Export builds a snapshot of the cache for the releases, or all releases when empty.
+func (c *Cache) Export(releases []string) (*Snapshot, error) {
+	if len(releases) == 0 {
+		var err error
+		releases, err = c.Releases()
+		if err != nil {
+			return nil, err
+		}
+	}
+	snap := &Snapshot{Version: snapshotVersion, CreatedAt: time.Now()}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, release := range releases {
+		cr := c.load(release)
+		if len(cr.Tests) == 0 {
+			log.Warnf("Sippy cache: no entries found for release %s", release)
+			continue
+		}
+		snap.Releases = append(snap.Releases, cr)
+	}
+	return snap, nil
+}
+
+// Import merges the snapshot into the cache, marking the entries with the source
+// snapshot. Existing entries are replaced only when the snapshot entry is newer.
+// Returns the number of entries imported.
+func (c *Cache) Import(snap *Snapshot) (int, error) {
+	if snap.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %q, expected %q", snap.Version, snapshotVersion)
+	}
+	for _, sr := range snap.Releases {
+		if err := validateRelease(sr.Release); err != nil {
+			return 0, fmt.Errorf("unable to import snapshot: %w", err)
+		}
+	}
+	count := 0
+	c.lock.Lock()
+	for _, sr := range snap.Releases {
+		cr := c.load(sr.Release)
+		for name, entry := range sr.Tests {
+			if cur, ok := cr.Tests[name]; ok && cur.UpdatedAt.After(entry.UpdatedAt) {
+				continue
+			}
+			cr.Tests[name] = &CacheEntry{
+				Response:  entry.Response,
+				UpdatedAt: entry.UpdatedAt,
+				Source:    DataSourceSnapshot,
+			}
+			count += 1
+		}
+		c.changed[sr.Release] = true
+	}
+	c.lock.Unlock()
+	return count, c.Save()
+}
+
+// LoadSnapshotFromFile reads a snapshot file.
+func LoadSnapshotFromFile(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot file: %w", err)
+	}
+	snap := &Snapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("unable to parse snapshot file %s: %w", path, err)
+	}
+	return snap, nil
+}
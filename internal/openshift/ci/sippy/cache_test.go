@@ -0,0 +1,105 @@
+package sippy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir, time.Hour)
+	resp := SippyTestsRequestOutput{{Name: "test-a", CurrentFlakePerc: 10.0}}
+	cache.Set("4.15", "test-a", resp)
+	assert.NoError(t, cache.Save())
+
+	reloaded := NewCache(dir, time.Hour)
+	entry, fresh := reloaded.Get("4.15", "test-a")
+	assert.NotNil(t, entry)
+	assert.True(t, fresh)
+	assert.Equal(t, resp, entry.Response)
+	assert.Equal(t, DataSourceAPI, entry.Source)
+
+	entry, _ = reloaded.Get("4.16", "test-a")
+	assert.Nil(t, entry)
+
+	releases, err := reloaded.Releases()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4.15"}, releases)
+
+	expired := NewCache(dir, time.Nanosecond)
+	entry, fresh = expired.Get("4.15", "test-a")
+	assert.NotNil(t, entry)
+	assert.False(t, fresh)
+}
+
+func TestCacheExportImport(t *testing.T) {
+	src := NewCache(t.TempDir(), 0)
+	src.Set("4.15", "test-a", SippyTestsRequestOutput{{Name: "test-a"}})
+	src.Set("4.16", "test-b", SippyTestsRequestOutput{{Name: "test-b"}})
+	assert.NoError(t, src.Save())
+
+	snap, err := src.Export([]string{"4.15"})
+	assert.NoError(t, err)
+	assert.Len(t, snap.Releases, 1)
+
+	dst := NewCache(t.TempDir(), 0)
+	count, err := dst.Import(snap)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	entry, _ := dst.Get("4.15", "test-a")
+	assert.NotNil(t, entry)
+	assert.Equal(t, DataSourceSnapshot, entry.Source)
+
+	_, err = dst.Import(&Snapshot{Version: "v0"})
+	assert.Error(t, err)
+}
+
+func TestCacheInvalidRelease(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "cache")
+	c := NewCache(dir, 0)
+	for _, release := range []string{"../escaped", "4.15/../../escaped", "/tmp/escaped", ".."} {
+		_, err := c.Import(&Snapshot{
+			Version:  snapshotVersion,
+			Releases: []*CacheRelease{{Release: release, Tests: map[string]*CacheEntry{"test-a": {}}}},
+		})
+		assert.Error(t, err, release)
+
+		c.Set(release, "test-a", SippyTestsRequestOutput{{Name: "test-a"}})
+		entry, _ := c.Get(release, "test-a")
+		assert.Nil(t, entry, release)
+	}
+	assert.NoError(t, c.Save())
+	_, err := os.Stat(filepath.Join(base, "escaped.json"))
+	assert.True(t, os.IsNotExist(err))
+	releases, err := c.Releases()
+	assert.NoError(t, err)
+	assert.Empty(t, releases)
+}
+
+func TestQueryTestsOffline(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir, time.Nanosecond)
+	cache.Set("4.15", "test-a", SippyTestsRequestOutput{{Name: "test-a", CurrentFlakePerc: 1.0}})
+	assert.NoError(t, cache.Save())
+
+	api := NewSippyAPIWithOptions("4.15", &Options{CacheDir: dir, CacheTTL: time.Nanosecond, Offline: true})
+	resp, err := api.QueryTests(&SippyTestsRequestInput{TestName: "test-a"})
+	assert.NoError(t, err)
+	assert.Len(t, *resp, 1)
+
+	_, err = api.QueryTests(&SippyTestsRequestInput{TestName: "test-b"})
+	assert.Error(t, err)
+
+	stats := api.GetStats()
+	assert.Equal(t, 1, stats.Cache)
+	assert.Equal(t, 1, stats.Stale)
+	assert.Equal(t, 1, stats.Unavailable)
+	assert.Equal(t, DataSourceCache, stats.Source)
+	assert.True(t, stats.Offline)
+}
@@ -1,12 +1,19 @@
 package sippy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -16,6 +23,18 @@ const (
 	defaultMaxIddleConnsPerHost = 100
 	apiBaseURL                  = "https://sippy.dptools.openshift.org/api"
 	apiPathTests                = "/tests"
+
+	// DefaultConcurrency is the default number of concurrent requests to the API.
+	DefaultConcurrency = 4
+	// DefaultRequestRate is the default limit of requests per second to the API.
+	DefaultRequestRate = 10
+	// DefaultMaxRetries is the default number of retries of a failed request.
+	DefaultMaxRetries = 3
+	// DefaultBatchSize is the default number of tests queried in a single request.
+	DefaultBatchSize = 10
+
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 10 * time.Second
 )
 
 type SippyTestsRequestInput struct {
@@ -28,6 +47,9 @@ type SippyTestsRequestInput struct {
 type SippyTestsRequestFilter struct {
 	// Example: {"items":[{"columnField":"name","operatorValue":"equals","value":"test_name"}]}
 	Items []SippyTestsRequestFilterItems `json:"items"`
+
+	// LinkOperator is the operator applied to the items: and (default), or.
+	LinkOperator string `json:"linkOperator,omitempty"`
 }
 
 // SippyTestsRequestFilterItems is the filter parameters
@@ -57,44 +79,270 @@ type SippyTestsRequestOutput []SippyTestsResponse
 type SippyAPI struct {
 	client     *http.Client
 	ocpVersion string
+	baseURL    string
+
+	concurrency int
+	batchSize   int
+
+	// limiter limits the requests per second, including the retries.
+	limiter *rate.Limiter
+
+	cache   *Cache
+	offline bool
+
+	statsLock sync.Mutex
+	stats     *DataSourceStats
+}
+
+// Options are the optional settings of the Sippy API client.
+type Options struct {
+	// CacheDir is the directory of the persistent cache. Default: DefaultCacheDir().
+	CacheDir string
+
+	// CacheTTL is the time a cached response is considered fresh. Default: DefaultCacheTTL.
+	CacheTTL time.Duration
+
+	// DisableCache disables the persistent cache.
+	DisableCache bool
+
+	// Offline uses only the cached data (including expired entries), without reaching the API.
+	Offline bool
+
+	// Concurrency is the maximum number of concurrent requests. Default: DefaultConcurrency.
+	Concurrency int
+
+	// RequestRate is the maximum number of requests per second. Default: DefaultRequestRate.
+	RequestRate int
+
+	// MaxRetries is the number of retries, with exponential backoff, of a failed
+	// request (connection errors, 429 and 5xx). Zero disables the retries.
+	// Default (nil): DefaultMaxRetries.
+	MaxRetries *int
+
+	// BatchSize is the maximum number of tests queried in a single request. Default: DefaultBatchSize.
+	BatchSize int
+}
+
+// DataSourceStats holds the counters of the data source used to answer each query.
+type DataSourceStats struct {
+	API         int    `json:"api"`
+	Cache       int    `json:"cache"`
+	Snapshot    int    `json:"snapshot"`
+	Stale       int    `json:"stale"`
+	Unavailable int    `json:"unavailable"`
+	CacheDir    string `json:"cacheDir,omitempty"`
+	Offline     bool   `json:"offline"`
+	Source      string `json:"source"`
+}
+
+// update sets the Source summarizing the data sources used.
+func (s *DataSourceStats) update() {
+	sources := []string{}
+	if s.API > 0 {
+		sources = append(sources, DataSourceAPI)
+	}
+	if s.Cache > 0 {
+		sources = append(sources, DataSourceCache)
+	}
+	if s.Snapshot > 0 {
+		sources = append(sources, DataSourceSnapshot)
+	}
+	switch {
+	case len(sources) > 0:
+		s.Source = strings.Join(sources, "+")
+	case s.Unavailable > 0:
+		s.Source = DataSourceUnavailable
+	default:
+		s.Source = "none"
+	}
 }
 
 // NewSippyAPI creates a new API setting the http attributes to improve the connection reuse.
 func NewSippyAPI(ocpVersion string) *SippyAPI {
+	limiter := rate.NewLimiter(rate.Limit(DefaultRequestRate), 1)
+	return &SippyAPI{
+		stats:       &DataSourceStats{},
+		ocpVersion:  ocpVersion,
+		baseURL:     apiBaseURL,
+		client:      newHTTPClient(DefaultMaxRetries, limiter),
+		concurrency: DefaultConcurrency,
+		batchSize:   DefaultBatchSize,
+		limiter:     limiter,
+	}
+}
+
+// rateLimitedTransport waits for the limiter before sending each request, the retries
+// of the retryable client reach the transport, so every attempt is rate limited.
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// newHTTPClient creates the http client retrying failed requests with exponential backoff,
+// limited by the request rate.
+func newHTTPClient(maxRetries int, limiter *rate.Limiter) *http.Client {
 	t := http.DefaultTransport.(*http.Transport).Clone()
 	t.MaxIdleConns = defaultMaxIdleConns
 	t.MaxConnsPerHost = defaultMaxConnsPerHost
 	t.MaxIdleConnsPerHost = defaultMaxIddleConnsPerHost
 
-	return &SippyAPI{
-		ocpVersion: ocpVersion,
-		client: &http.Client{
-			Timeout:   defaultConnTimeoutSec * time.Second,
-			Transport: t,
-		},
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = maxRetries
+	retryClient.RetryWaitMin = defaultRetryWaitMin
+	retryClient.RetryWaitMax = defaultRetryWaitMax
+	retryLogger := log.New()
+	retryLogger.SetLevel(log.WarnLevel)
+	retryClient.Logger = retryLogger
+	retryClient.HTTPClient = &http.Client{
+		Timeout:   defaultConnTimeoutSec * time.Second,
+		Transport: &rateLimitedTransport{next: t, limiter: limiter},
+	}
+	return retryClient.StandardClient()
+}
+
+// NewSippyAPIWithOptions creates a new API with the persistent cache enabled by default.
+func NewSippyAPIWithOptions(ocpVersion string, opts *Options) *SippyAPI {
+	a := NewSippyAPI(ocpVersion)
+	if opts == nil {
+		opts = &Options{}
+	}
+	if !opts.DisableCache || opts.Offline {
+		a.cache = NewCache(opts.CacheDir, opts.CacheTTL)
+		a.stats.CacheDir = a.cache.Dir()
+	}
+	a.offline = opts.Offline
+	a.stats.Offline = opts.Offline
+	if opts.Concurrency > 0 {
+		a.concurrency = opts.Concurrency
+	}
+	if opts.RequestRate > 0 {
+		a.limiter.SetLimit(rate.Limit(opts.RequestRate))
+	}
+	if opts.BatchSize > 0 {
+		a.batchSize = opts.BatchSize
+	}
+	if opts.MaxRetries != nil {
+		a.client = newHTTPClient(*opts.MaxRetries, a.limiter)
+	}
+	return a
+}
+
+// GetStats returns a copy of the data source counters.
+func (a *SippyAPI) GetStats() *DataSourceStats {
+	a.statsLock.Lock()
+	defer a.statsLock.Unlock()
+	stats := *a.stats
+	stats.update()
+	return &stats
+}
+
+// addStats increments the counter of the data source.
+func (a *SippyAPI) addStats(source string, stale bool) {
+	a.statsLock.Lock()
+	defer a.statsLock.Unlock()
+	switch source {
+	case DataSourceAPI:
+		a.stats.API += 1
+	case DataSourceCache:
+		a.stats.Cache += 1
+	case DataSourceSnapshot:
+		a.stats.Snapshot += 1
+	default:
+		a.stats.Unavailable += 1
+	}
+	if stale {
+		a.stats.Stale += 1
 	}
 }
 
+// SaveCache persists the cached responses to disk.
+func (a *SippyAPI) SaveCache() error {
+	if a.cache == nil {
+		return nil
+	}
+	return a.cache.Save()
+}
+
 // QueryTests receive a input with attributes to query the results of a single test
 // by name on the CI, returning the list with result items.
+// When the cache is enabled, fresh cached entries are used, and expired entries
+// are used as fallback when the API is not reachable (or in offline mode).
 func (a *SippyAPI) QueryTests(in *SippyTestsRequestInput) (*SippyTestsRequestOutput, error) {
+	if a.cache == nil {
+		resp, err := a.queryTestsAPI(context.Background(), newTestsFilter([]string{in.TestName}))
+		if err != nil {
+			a.addStats(DataSourceUnavailable, false)
+			return nil, err
+		}
+		a.addStats(DataSourceAPI, false)
+		return resp, nil
+	}
+
+	entry, fresh := a.cache.Get(a.ocpVersion, in.TestName)
+	if entry != nil && (fresh || a.offline) {
+		a.addStats(entrySource(entry), !fresh)
+		return &entry.Response, nil
+	}
+	if a.offline {
+		a.addStats(DataSourceUnavailable, false)
+		return nil, fmt.Errorf("test not found in the cache (offline mode): %s", in.TestName)
+	}
+
+	resp, err := a.queryTestsAPI(context.Background(), newTestsFilter([]string{in.TestName}))
+	if err != nil {
+		if entry != nil {
+			log.Warnf("Sippy API: using expired cache entry from %s for test %q: %v", entry.UpdatedAt.Format(time.RFC3339), in.TestName, err)
+			a.addStats(entrySource(entry), true)
+			return &entry.Response, nil
+		}
+		a.addStats(DataSourceUnavailable, false)
+		return nil, err
+	}
+	a.cache.Set(a.ocpVersion, in.TestName, *resp)
+	a.addStats(DataSourceAPI, false)
+	return resp, nil
+}
+
+// entrySource returns the data source of a cache entry.
+func entrySource(entry *CacheEntry) string {
+	if entry.Source == DataSourceSnapshot {
+		return DataSourceSnapshot
+	}
+	return DataSourceCache
+}
 
-	filter := SippyTestsRequestFilter{
-		Items: []SippyTestsRequestFilterItems{
-			{
-				ColumnField:   "name",
-				OperatorValue: "equals",
-				Value:         in.TestName,
-			},
-		},
+// newTestsFilter builds the filter matching the test names, linking the items
+// with the 'or' operator when querying more than one test.
+func newTestsFilter(names []string) SippyTestsRequestFilter {
+	filter := SippyTestsRequestFilter{}
+	for _, name := range names {
+		filter.Items = append(filter.Items, SippyTestsRequestFilterItems{
+			ColumnField:   "name",
+			OperatorValue: "equals",
+			Value:         name,
+		})
 	}
+	if len(names) > 1 {
+		filter.LinkOperator = "or"
+	}
+	return filter
+}
 
+// queryTestsAPI queries the tests matching the filter in the Sippy API.
+func (a *SippyAPI) queryTestsAPI(ctx context.Context, filter SippyTestsRequestFilter) (*SippyTestsRequestOutput, error) {
 	b, err := json.Marshal(filter)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't parse response body. %+v", err)
 	}
 
-	baseUrl, err := url.Parse(apiBaseURL + apiPathTests)
+	baseUrl, err := url.Parse(a.baseURL + apiPathTests)
 	if err != nil {
 		return nil, fmt.Errorf("malformed URL: %+v", err)
 	}
@@ -105,7 +353,7 @@ func (a *SippyAPI) QueryTests(in *SippyTestsRequestInput) (*SippyTestsRequestOut
 
 	baseUrl.RawQuery = params.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, baseUrl.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseUrl.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create the request: %+v", err)
 	}
@@ -0,0 +1,242 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Acceptance status of a baseline result.
+const (
+	AcceptanceStatusAccepted             = "accepted"
+	AcceptanceStatusAcceptedWithWarnings = "accepted-with-warnings"
+	AcceptanceStatusRejected             = "rejected"
+
+	// AcceptancePolicyDefault is the name of the default policy.
+	AcceptancePolicyDefault = "default"
+)
+
+// AcceptancePolicy is the policy evaluated to accept a result to be published as
+// baseline ('opct adm baseline publish --policy').
+type AcceptancePolicy struct {
+	// Blocking are the check IDs rejecting the baseline when failed.
+	Blocking []string `yaml:"blocking,omitempty"`
+
+	// Warning are the check IDs reported as warnings when failed, or in warning state.
+	Warning []string `yaml:"warning,omitempty"`
+
+	// Thresholds are the numeric sanity thresholds, rejecting the baseline when not met.
+	Thresholds *AcceptanceThresholds `yaml:"thresholds,omitempty"`
+
+	name string
+}
+
+// AcceptanceThresholds are the numeric sanity thresholds of the acceptance policy.
+type AcceptanceThresholds struct {
+	// MinTestsPerPlugin is the minimum number of tests executed by plugin name.
+	// Example: {"20-openshift-conformance-validated": 1000}
+	MinTestsPerPlugin map[string]int64 `yaml:"minTestsPerPlugin,omitempty"`
+
+	// MaxEtcdSlowRequestsAvg is the maximum average (ms) of etcd slow requests
+	// parsed from must-gather logs. Zero disables the threshold.
+	MaxEtcdSlowRequestsAvg float64 `yaml:"maxEtcdSlowRequestsAvg,omitempty"`
+}
+
+// AcceptanceItem is a rejection or warning of the acceptance policy.
+type AcceptanceItem struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// AcceptanceResult is the evaluation of the acceptance policy, saved in the
+// published summary.
+type AcceptanceResult struct {
+	Policy     string            `json:"policy"`
+	Status     string            `json:"status"`
+	Rejections []*AcceptanceItem `json:"rejections,omitempty"`
+	Warnings   []*AcceptanceItem `json:"warnings,omitempty"`
+}
+
+// Accepted returns true when the result has no rejections.
+func (ar *AcceptanceResult) Accepted() bool {
+	return len(ar.Rejections) == 0
+}
+
+// WarningIDs returns the IDs of the warnings.
+func (ar *AcceptanceResult) WarningIDs() []string {
+	ids := []string{}
+	for _, w := range ar.Warnings {
+		ids = append(ids, w.ID)
+	}
+	return ids
+}
+
+// NewDefaultAcceptancePolicy returns the default acceptance policy, rejecting the baseline when:
+// OPCT-001 : kube conformance failing
+// OPCT-004 : too many tests failed on openshift conformance
+// OPCT-005 : collector must be able to collect the results
+// OPCT-022: potential runtime failure
+func NewDefaultAcceptancePolicy() *AcceptancePolicy {
+	return &AcceptancePolicy{
+		Blocking: []string{CheckID001, CheckID004, CheckID005, CheckID022},
+		name:     AcceptancePolicyDefault,
+	}
+}
+
+// LoadAcceptancePolicyFromFile reads and validates the acceptance policy file. The
+// check IDs must be defined by the default check rules.
+func LoadAcceptancePolicyFromFile(path string) (*AcceptancePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read acceptance policy file: %w", err)
+	}
+	policy := &AcceptancePolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("unable to parse acceptance policy file %s: %w", path, err)
+	}
+	known := NewDefaultCheckRules().IDs()
+	unknown := []string{}
+	blocking := make(map[string]struct{}, len(policy.Blocking))
+	for _, id := range policy.Blocking {
+		if _, ok := known[id]; !ok {
+			unknown = append(unknown, id)
+		}
+		blocking[id] = struct{}{}
+	}
+	for _, id := range policy.Warning {
+		if _, ok := known[id]; !ok {
+			unknown = append(unknown, id)
+		}
+		if _, ok := blocking[id]; ok {
+			return nil, fmt.Errorf("invalid acceptance policy %s: check %s must be blocking or warning, not both", path, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("invalid acceptance policy %s: unknown check IDs: %s", path, strings.Join(unknown, ", "))
+	}
+	if policy.Thresholds != nil && policy.Thresholds.MaxEtcdSlowRequestsAvg < 0 {
+		return nil, fmt.Errorf("invalid acceptance policy %s: maxEtcdSlowRequestsAvg must be positive", path)
+	}
+	policy.name = path
+	return policy, nil
+}
+
+// findCheck returns the check output and the result by ID.
+func findCheck(checks *ReportChecks, id string) (*SLOOutput, CheckResultName) {
+	if checks == nil {
+		return nil, ""
+	}
+	for result, outputs := range map[CheckResultName][]*SLOOutput{
+		CheckResultNameFail:   checks.Fail,
+		CheckResultNameWarn:   checks.Warn,
+		CheckResultNamePass:   checks.Pass,
+		CheckResultNameSkip:   checks.Skip,
+		CheckResultNameWaived: checks.Waived,
+	} {
+		for _, check := range outputs {
+			if check.ID == id {
+				return check, result
+			}
+		}
+	}
+	return nil, ""
+}
+
+// checkMessage returns the message of the check to report in the evaluation.
+func checkMessage(check *SLOOutput, result CheckResultName) string {
+	msg := fmt.Sprintf("%s: %q: want=%q, got=%q", result, check.SLO, check.SLITarget, check.SLIActual)
+	if check.Message != "" {
+		msg = fmt.Sprintf("%s: message=%q", msg, check.Message)
+	}
+	if check.Waiver != nil {
+		msg = fmt.Sprintf("%s: waived by %s until %s: %q", msg, check.Waiver.Approver, check.Waiver.Expires, check.Waiver.Justification)
+	}
+	return msg
+}
+
+// etcdSlowRequestsAvg returns the average of etcd slow requests parsed from must-gather logs.
+func etcdSlowRequestsAvg(re *ReportData) (float64, error) {
+	if re.Provider == nil || re.Provider.MustGatherInfo == nil || re.Provider.MustGatherInfo.ErrorEtcdLogs == nil {
+		return 0, fmt.Errorf("etcd logs not available")
+	}
+	stat := re.Provider.MustGatherInfo.ErrorEtcdLogs.FilterRequestSlowAll["all"]
+	if stat == nil || stat.StatMean == "" {
+		return 0, fmt.Errorf("etcd slow requests statistics not available")
+	}
+	return strconv.ParseFloat(strings.Split(stat.StatMean, " ")[0], 64)
+}
+
+// Evaluate evaluates the policy over the report data. Checks must have been
+// evaluated (Populate).
+func (ap *AcceptancePolicy) Evaluate(re *ReportData) *AcceptanceResult {
+	res := &AcceptanceResult{Policy: ap.name}
+	for _, id := range ap.Blocking {
+		check, result := findCheck(re.Checks, id)
+		if check == nil {
+			res.Rejections = append(res.Rejections, &AcceptanceItem{ID: id, Message: "blocking check not found in the report"})
+			continue
+		}
+		switch result {
+		case CheckResultNameFail:
+			res.Rejections = append(res.Rejections, &AcceptanceItem{ID: id, Message: checkMessage(check, result)})
+		case CheckResultNameWaived:
+			// waived failures are accepted, reported as warnings.
+			res.Warnings = append(res.Warnings, &AcceptanceItem{ID: id, Message: checkMessage(check, result)})
+		}
+	}
+	for _, id := range ap.Warning {
+		check, result := findCheck(re.Checks, id)
+		if check == nil {
+			res.Warnings = append(res.Warnings, &AcceptanceItem{ID: id, Message: "warning check not found in the report"})
+			continue
+		}
+		if result == CheckResultNameFail || result == CheckResultNameWarn || result == CheckResultNameWaived {
+			res.Warnings = append(res.Warnings, &AcceptanceItem{ID: id, Message: checkMessage(check, result)})
+		}
+	}
+
+	if ap.Thresholds != nil {
+		plugins := make([]string, 0, len(ap.Thresholds.MinTestsPerPlugin))
+		for name := range ap.Thresholds.MinTestsPerPlugin {
+			plugins = append(plugins, name)
+		}
+		sort.Strings(plugins)
+		for _, name := range plugins {
+			id := "minTestsPerPlugin/" + name
+			want := ap.Thresholds.MinTestsPerPlugin[name]
+			var total int64
+			if re.Provider != nil {
+				if p, ok := re.Provider.Plugins[name]; ok && p.Stat != nil {
+					total = p.Stat.Total
+				}
+			}
+			if total < want {
+				res.Rejections = append(res.Rejections, &AcceptanceItem{ID: id, Message: fmt.Sprintf("want=>=%d, got=%d", want, total)})
+			}
+		}
+		if want := ap.Thresholds.MaxEtcdSlowRequestsAvg; want > 0 {
+			id := "maxEtcdSlowRequestsAvg"
+			got, err := etcdSlowRequestsAvg(re)
+			switch {
+			case err != nil:
+				res.Warnings = append(res.Warnings, &AcceptanceItem{ID: id, Message: fmt.Sprintf("unable to evaluate threshold: %v", err)})
+			case got > want:
+				res.Rejections = append(res.Rejections, &AcceptanceItem{ID: id, Message: fmt.Sprintf("want=<=%.2f ms, got=%.3f ms", want, got)})
+			}
+		}
+	}
+
+	switch {
+	case !res.Accepted():
+		res.Status = AcceptanceStatusRejected
+	case len(res.Warnings) > 0:
+		res.Status = AcceptanceStatusAcceptedWithWarnings
+	default:
+		res.Status = AcceptanceStatusAccepted
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+)
+
+func TestAcceptancePolicyEvaluate(t *testing.T) {
+	newReport := func() *ReportData {
+		return &ReportData{
+			Provider: &ReportResult{
+				Plugins: map[string]*ReportPlugin{
+					"20-openshift-conformance-validated": {Stat: &ReportPluginStat{Total: 900}},
+				},
+				MustGatherInfo: &mustgather.MustGather{
+					ErrorEtcdLogs: &mustgather.ErrorEtcdLogs{
+						FilterRequestSlowAll: map[string]*mustgather.BucketFilterStat{
+							"all": {StatMean: "350.5 ms"},
+						},
+					},
+				},
+			},
+			Checks: &ReportChecks{
+				Fail: []*SLOOutput{{ID: CheckID004, SLO: "tests failed"}},
+				Warn: []*SLOOutput{{ID: "OPCT-010A"}},
+				Pass: []*SLOOutput{{ID: CheckID001}, {ID: CheckID005}, {ID: CheckID022}},
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		policy     *AcceptancePolicy
+		status     string
+		rejections []string
+		warnings   []string
+	}{
+		{
+			name:       "default policy",
+			policy:     NewDefaultAcceptancePolicy(),
+			status:     AcceptanceStatusRejected,
+			rejections: []string{CheckID004},
+			warnings:   []string{},
+		},
+		{
+			name:       "warning only",
+			policy:     &AcceptancePolicy{Blocking: []string{CheckID001}, Warning: []string{CheckID004, "OPCT-010A", CheckID005}},
+			status:     AcceptanceStatusAcceptedWithWarnings,
+			rejections: []string{},
+			warnings:   []string{CheckID004, "OPCT-010A"},
+		},
+		{
+			name:       "unknown check",
+			policy:     &AcceptancePolicy{Blocking: []string{"OPCT-999"}},
+			status:     AcceptanceStatusRejected,
+			rejections: []string{"OPCT-999"},
+			warnings:   []string{},
+		},
+		{
+			name: "thresholds",
+			policy: &AcceptancePolicy{Thresholds: &AcceptanceThresholds{
+				MinTestsPerPlugin:      map[string]int64{"20-openshift-conformance-validated": 1000, "10-openshift-kube-conformance": 1},
+				MaxEtcdSlowRequestsAvg: 300,
+			}},
+			status:     AcceptanceStatusRejected,
+			rejections: []string{"minTestsPerPlugin/10-openshift-kube-conformance", "minTestsPerPlugin/20-openshift-conformance-validated", "maxEtcdSlowRequestsAvg"},
+			warnings:   []string{},
+		},
+		{
+			name: "thresholds accepted",
+			policy: &AcceptancePolicy{Thresholds: &AcceptanceThresholds{
+				MinTestsPerPlugin:      map[string]int64{"20-openshift-conformance-validated": 500},
+				MaxEtcdSlowRequestsAvg: 500,
+			}},
+			status:     AcceptanceStatusAccepted,
+			rejections: []string{},
+			warnings:   []string{},
+		},
+	}
+	ids := func(items []*AcceptanceItem) []string {
+		res := []string{}
+		for _, item := range items {
+			res = append(res, item.ID)
+		}
+		return res
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.policy.Evaluate(newReport())
+			assert.Equal(t, tt.status, res.Status)
+			assert.Equal(t, tt.rejections, ids(res.Rejections))
+			assert.Equal(t, tt.warnings, ids(res.Warnings))
+			assert.Equal(t, len(tt.rejections) == 0, res.Accepted())
+		})
+	}
+}
+
+func TestLoadAcceptancePolicyFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	policy, err := LoadAcceptancePolicyFromFile(write("valid.yaml", `
+blocking: ["OPCT-001"]
+warning: ["OPCT-004"]
+thresholds:
+  minTestsPerPlugin:
+    20-openshift-conformance-validated: 1000
+  maxEtcdSlowRequestsAvg: 500
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"OPCT-001"}, policy.Blocking)
+	assert.Equal(t, int64(1000), policy.Thresholds.MinTestsPerPlugin["20-openshift-conformance-validated"])
+	assert.Equal(t, filepath.Join(dir, "valid.yaml"), policy.Evaluate(&ReportData{}).Policy)
+
+	_, err = LoadAcceptancePolicyFromFile(write("both.yaml", "blocking: [OPCT-001]\nwarning: [OPCT-001]\n"))
+	assert.Error(t, err)
+	_, err = LoadAcceptancePolicyFromFile(write("unknown.yaml", "blockers: [OPCT-001]\n"))
+	assert.Error(t, err)
+	_, err = LoadAcceptancePolicyFromFile(write("typo.yaml", "blocking: [OPCT-01]\nwarning: [OPCT-004]\n"))
+	assert.ErrorContains(t, err, "unknown check IDs: OPCT-01")
+	_, err = LoadAcceptancePolicyFromFile(filepath.Join(dir, "not-found.yaml"))
+	assert.Error(t, err)
+}
@@ -10,11 +10,17 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
-// createS3Client creates an S3 client with the specified region
-func createS3Client(region string) (*s3.S3, *s3manager.Uploader, error) {
-	sess, err := session.NewSession(&aws.Config{
+// createS3Client creates an S3 client with the specified region. The endpoint is set
+// to use S3-compatible storage (MinIO), with path-style addressing.
+func createS3Client(region, endpoint string) (*s3.S3, *s3manager.Uploader, error) {
+	cfg := &aws.Config{
 		Region: aws.String(region),
-	})
+	}
+	if endpoint != "" {
+		cfg.Endpoint = aws.String(endpoint)
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(cfg)
 	if err != nil {
 		return nil, nil, err
 	}
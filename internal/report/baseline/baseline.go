@@ -13,19 +13,17 @@ package baseline
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
-	"github.com/hashicorp/go-retryablehttp"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	bucketNameBaselineReportSummary     = "opct-archive"
 	indexObjectKey                      = "api/v0/result/summary/index.json"
 	objectPathBaselineReportSummaryPath = "/result/summary/index.json"
 
@@ -46,60 +44,50 @@ const (
 // BaselineReport is the struct that holds the baseline report data
 // pre-processed and saved in the bucket.
 type BaselineConfig struct {
-	bucketName               string
-	bucketRegion             string
-	cloudfrontDistributionID string
+	store BaselineStore
 
 	buffer *BaselineData
+
+	// buffers are the most recent summaries loaded, sorted from newest to oldest.
+	buffers []*BaselineData
+
+	// integrity are the options to verify the summaries, and index the index
+	// used to verify them.
+	integrity *IntegrityOptions
+	index     *baselineIndex
 }
 
-// NewBaselineReportSummary creates a new BaselineConfig struct with the default
-// configuration allowing customization to chage the S3 storage used in the management
-// tasks.
-// TODO deprecate the environment variables when backend is fully migrated to dedicated
-// AWS account.
+// NewBaselineReportSummary creates a new BaselineConfig struct reading the baseline
+// results from the default results API (DefaultStoreURL).
 func NewBaselineReportSummary() *BaselineConfig {
-	bucketName := opctStorageBucketName
-	bucketRegion := opctStorageBucketRegion
-	if os.Getenv("OPCT_EXP_BUCKET_NAME") != "" {
-		log.Warnf("using custom bucket name: %s", os.Getenv("OPCT_EXP_BUCKET_NAME"))
-		bucketName = os.Getenv("OPCT_EXP_BUCKET_NAME")
-	}
-	if os.Getenv("OPCT_EXP_BUCKET_REGION") != "" {
-		log.Warnf("using custom bucket region: %s", os.Getenv("OPCT_EXP_BUCKET_REGION"))
-		bucketRegion = os.Getenv("OPCT_EXP_BUCKET_REGION")
-	}
-	return &BaselineConfig{
-		bucketName:               bucketName,
-		bucketRegion:             bucketRegion,
-		cloudfrontDistributionID: cloudfrontDistributionID,
-	}
+	u, _ := url.Parse(DefaultStoreURL())
+	return &BaselineConfig{store: newHTTPStore(u)}
 }
 
-// createS3Clients creates the S3 client and uploader to interact with the S3 storage, checking if
-// bucket exists.
-func (brs *BaselineConfig) createS3Clients() (*s3.S3, *s3manager.Uploader, error) {
-	if !brs.checkRequiredParams() {
-		return nil, nil, fmt.Errorf("missing required parameters or dependencies to enable this feature")
-	}
-
-	// create s3 client
-	svcS3, uploader, err := createS3Client(brs.bucketRegion)
+// NewBaselineReportSummaryFromURL creates a new BaselineConfig struct using the store
+// selected by the URL (see NewBaselineStore), allowing the management tasks to use
+// private or local storage.
+func NewBaselineReportSummaryFromURL(storeURL string) (*BaselineConfig, error) {
+	store, err := NewBaselineStore(storeURL)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	return &BaselineConfig{store: store}, nil
+}
 
-	// Check if the bucket exists
-	bucketExists, err := checkBucketExists(svcS3, brs.bucketName)
-	if err != nil {
-		return nil, nil, err
-	}
+// GetStore returns the store of baseline results.
+func (brs *BaselineConfig) GetStore() BaselineStore {
+	return brs.store
+}
 
-	if !bucketExists {
-		return nil, nil, fmt.Errorf("the OPCT storage does not exists")
+// checkWriteAllowed checks if write operations are allowed in the store. Write
+// operations in the AWS S3 store backing the OPCT results API require the
+// environment variable OPCT_ENABLE_ADM_BASELINE=1.
+func (brs *BaselineConfig) checkWriteAllowed() error {
+	if st, ok := brs.store.(*s3Store); ok && st.isAWS() && !brs.checkRequiredParams() {
+		return fmt.Errorf("missing required parameters or dependencies to enable this feature")
 	}
-
-	return svcS3, uploader, nil
+	return nil
 }
 
 // ReadReportSummaryIndexFromAPI reads the summary report index from the OPCT report URL.
@@ -118,40 +106,10 @@ func (brs *BaselineConfig) ReadReportSummaryIndexFromAPI() (*baselineIndex, erro
 	return index, nil
 }
 
-// ReadReportSummaryFromAPI reads the summary report from the external URL.
+// ReadReportSummaryFromAPI reads the summary report from the results API path.
+// Example path: /result/summary/index.json
 func (brs *BaselineConfig) ReadReportSummaryFromAPI(path string) ([]byte, error) {
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 5
-	retryLogger := log.New()
-	retryLogger.SetLevel(log.WarnLevel)
-	retryClient.Logger = retryLogger
-
-	url := fmt.Sprintf("%s%s", reportBaseURL, path)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
-	}
-	req.Header.Set("X-Custom-Header", "opct")
-	req.Header.Set("Content-Type", "application/json")
-
-	client := retryClient.StandardClient()
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error sending request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	log.Debugf("Summary Report API (%s) response code: %s", path, resp.Status)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("error BaselineAPI request: %s", resp.Status)
-	}
-
-	rawResp, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-
-	return rawResp, nil
+	return brs.store.Get(apiKeyPrefix + path)
 }
 
 // GetLatestRawSummaryFromPlatformWithFallback reads the latest summary report from the OPCT report
@@ -178,13 +136,66 @@ func (brs *BaselineConfig) GetLatestRawSummaryFromPlatformWithFallback(ocpReleas
 			errCount++
 			continue
 		}
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if err := brs.verifySummary(name, body); err != nil {
+			return err
+		}
 		brs.buffer = &BaselineData{}
 		brs.buffer.SetRawData(body)
+		brs.buffer.SetName(name)
 		return nil
 	}
 	return lastError
 }
 
+// GetRecentSummariesFromPlatformWithFallback reads up to 'count' most recent summaries of the
+// release and platform listed in the index, falling back to "None", and "AWS", when there are
+// no summaries for the platform. The latest summary is used when the index is not available.
+func (brs *BaselineConfig) GetRecentSummariesFromPlatformWithFallback(ocpRelease, platformType string, count int) error {
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	if err != nil {
+		log.Warnf("unable to read the baseline index, using the latest summary: %v", err)
+		if err := brs.GetLatestRawSummaryFromPlatformWithFallback(ocpRelease, platformType); err != nil {
+			return err
+		}
+		brs.buffers = []*BaselineData{brs.buffer}
+		return nil
+	}
+
+	for _, platform := range []string{platformType, "None", "AWS"} {
+		items := index.GetRecentByPlatform(ocpRelease, platform, count)
+		if len(items) == 0 {
+			continue
+		}
+		brs.buffers = []*BaselineData{}
+		for _, item := range items {
+			body, err := brs.GetSummaryByName(item.Name)
+			if errors.Is(err, ErrIntegrity) {
+				return err
+			}
+			if err != nil {
+				log.Warnf("unable to read baseline summary %s, skipping: %v", item.Name, err)
+				continue
+			}
+			bd := &BaselineData{}
+			bd.SetRawData(body)
+			bd.SetName(item.Name)
+			brs.buffers = append(brs.buffers, bd)
+		}
+		if len(brs.buffers) > 0 {
+			brs.buffer = brs.buffers[0]
+			log.Debugf("Loaded %d baseline summaries for %s_%s", len(brs.buffers), ocpRelease, platform)
+			return nil
+		}
+	}
+	log.Warnf("no baseline summaries found in the index for %s_%s, using the latest summary", ocpRelease, platformType)
+	if err := brs.GetLatestRawSummaryFromPlatformWithFallback(ocpRelease, platformType); err != nil {
+		return err
+	}
+	brs.buffers = []*BaselineData{brs.buffer}
+	return nil
+}
+
 // GetLatestSummaryByPlatform reads the latest summary report from the OPCT report service, trying to
 // retrieve from release and platform.
 // ocpRelease is the OpenShift major version, like "4.7", "4.8", etc.
@@ -194,13 +205,27 @@ func (brs *BaselineConfig) GetLatestSummaryByPlatform(ocpRelease, platformType s
 	if err != nil {
 		return fmt.Errorf("unable to get latest summary by platform: %w", err)
 	}
+	name := strings.TrimSuffix(filepath.Base(path), ".json")
+	if err := brs.verifySummary(name, buf); err != nil {
+		return err
+	}
 	brs.buffer = &BaselineData{}
 	brs.buffer.SetRawData(buf)
+	brs.buffer.SetName(name)
 	return nil
 }
 
+// GetSummaryByName reads the summary by name, verifying the integrity with the
+// hash recorded in the index (see SetIntegrityOptions).
 func (brs *BaselineConfig) GetSummaryByName(name string) ([]byte, error) {
-	return brs.ReadReportSummaryFromAPI(fmt.Sprintf("/result/summary/%s.json", name))
+	data, err := brs.ReadReportSummaryFromAPI(fmt.Sprintf("/result/summary/%s.json", name))
+	if err != nil {
+		return nil, err
+	}
+	if err := brs.verifySummary(name, data); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
 // checkRequiredParams checks if the required env to enable feature is set, then
@@ -216,3 +241,9 @@ func (brs *BaselineConfig) GetBuffer() *BaselineData {
 	}
 	return brs.buffer
 }
+
+// GetBuffers returns the summaries loaded by GetRecentSummariesFromPlatformWithFallback,
+// sorted from newest to oldest.
+func (brs *BaselineConfig) GetBuffers() []*BaselineData {
+	return brs.buffers
+}
@@ -14,7 +14,18 @@ import (
 // - internal/report
 // - internal/opct/summary
 type BaselineData struct {
-	raw []byte
+	raw  []byte
+	name string
+}
+
+// SetName sets the name of the baseline summary. Example: 4.15_None_latest.
+func (bd *BaselineData) SetName(name string) {
+	bd.name = name
+}
+
+// GetName returns the name of the baseline summary.
+func (bd *BaselineData) GetName() string {
+	return bd.name
 }
 
 func (bd *BaselineData) SetRawData(data []byte) {
@@ -25,53 +36,71 @@ func (bd *BaselineData) GetRawData() []byte {
 	return bd.raw
 }
 
+// baselineTestFailure is the failed test in the plugin summary.
+type baselineTestFailure struct {
+	Name string `json:"name"`
+}
+
+// baselinePlugins is the subset of the summary with the plugin failures.
+type baselinePlugins struct {
+	Provider *struct {
+		Plugins map[string]*struct {
+			ID             string                 `json:"id"`
+			FailedFiltered []*baselineTestFailure `json:"failedFiltered"`
+			FailedPriority []*baselineTestFailure `json:"failedPriority"`
+		} `json:"plugins"`
+	} `json:"provider"`
+}
+
 // GetPriorityFailuresFromPlugin returns the priority failures from a specific plugin.
 // The priority failures are the failures that are marked as priority in the baseline
-// report. It should be a temporary function while marshaling the data from the AP
-// isn't possible.
+// report: failedFiltered, or failedPriority on summaries created before the filter
+// pipeline. It should be a temporary function while marshaling the data from the AP
+// isn't possible. Summaries without plugins return an error.
 func (bd *BaselineData) GetPriorityFailuresFromPlugin(pluginName string) ([]string, error) {
-	failureStr := []string{}
-	var baselineData map[string]interface{}
-	err := json.Unmarshal(bd.raw, &baselineData)
-	if err != nil {
+	var obj baselinePlugins
+	if err := json.Unmarshal(bd.raw, &obj); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal baseline data: %w", err)
 	}
+	if obj.Provider == nil || obj.Provider.Plugins == nil {
+		return nil, fmt.Errorf("missing provider.plugins in baseline data")
+	}
 
-	// cast the data extracting the plugin failures.
-	for p := range baselineData["provider"].(map[string]interface{})["plugins"].(map[string]interface{}) {
-		pluginBaseline := baselineData["provider"].(map[string]interface{})["plugins"].(map[string]interface{})[p]
-		pluginID := pluginBaseline.(map[string]interface{})["id"]
-		if pluginID != pluginName {
+	failureStr := []string{}
+	for _, p := range obj.Provider.Plugins {
+		if p == nil || p.ID != pluginName {
 			continue
 		}
-		failures, ok := pluginBaseline.(map[string]interface{})["failedFiltered"]
-		if !ok || failures == nil {
-			failures, ok2 := pluginBaseline.(map[string]interface{})["failedPriority"]
-			if !ok2 {
-				log.Debugf("BaselineAPI data for plugin %q is missing failures (failedPriority), skipping...", pluginName)
-				return failureStr, nil
-			}
-			if failures == nil {
-				log.Debugf("BaselineAPI data for plugin %q is missing failures (failedFiltered), skipping...", pluginName)
-				return failureStr, nil
-			}
+		failures := p.FailedFiltered
+		if failures == nil {
+			failures = p.FailedPriority
+		}
+		if failures == nil {
+			log.Debugf("BaselineAPI data for plugin %q is missing failures (failedFiltered, failedPriority), skipping...", pluginName)
+			continue
 		}
-		for _, f := range failures.([]interface{}) {
-			failureStr = append(failureStr, f.(map[string]interface{})["name"].(string))
+		for _, f := range failures {
+			if f == nil || f.Name == "" {
+				continue
+			}
+			failureStr = append(failureStr, f.Name)
 		}
 	}
 	return failureStr, nil
 }
 
 func (bd *BaselineData) GetSetupTags() (map[string]interface{}, error) {
-	var tags map[string]interface{}
-	var obj map[string]interface{}
+	var obj struct {
+		Setup struct {
+			API map[string]interface{} `json:"api"`
+		} `json:"setup"`
+	}
 	err := json.Unmarshal(bd.raw, &obj)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal baseline data: %w", err)
 	}
-
-	fmt.Println(obj["setup"].(map[string]interface{}))
-	tags = obj["setup"].(map[string]interface{})["api"].(map[string]interface{})
-	return tags, nil
+	if obj.Setup.API == nil {
+		return nil, fmt.Errorf("missing setup.api in baseline data")
+	}
+	return obj.Setup.API, nil
 }
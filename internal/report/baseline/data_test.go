@@ -0,0 +1,39 @@
+package baseline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPriorityFailuresFromPlugin(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{"failedFiltered", `{"provider":{"plugins":{"p1":{"id":"p1","failedFiltered":[{"name":"t1"}],"failedPriority":[{"name":"t2"}]}}}}`, []string{"t1"}, false},
+		{"only failedPriority", `{"provider":{"plugins":{"p1":{"id":"p1","failedPriority":[{"name":"t2"}]}}}}`, []string{"t2"}, false},
+		{"null failedFiltered", `{"provider":{"plugins":{"p1":{"id":"p1","failedFiltered":null,"failedPriority":[{"name":"t2"}]}}}}`, []string{"t2"}, false},
+		{"without failures", `{"provider":{"plugins":{"p1":{"id":"p1"}}}}`, []string{}, false},
+		{"other plugin", `{"provider":{"plugins":{"p2":{"id":"p2","failedFiltered":[{"name":"t1"}]}}}}`, []string{}, false},
+		{"no provider", `{"setup":{}}`, nil, true},
+		{"no plugins", `{"provider":{}}`, nil, true},
+		{"invalid type", `{"provider":{"plugins":{"p1":{"id":"p1","failedFiltered":"t1"}}}}`, nil, true},
+		{"invalid json", `{`, nil, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bd := &BaselineData{}
+			bd.SetRawData([]byte(tc.raw))
+			got, err := bd.GetPriorityFailuresFromPlugin("p1")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// History timeline symbols, from the oldest to the newest baseline.
+const (
+	historyFailed = "F"
+	historyPassed = "."
+)
+
+// TestHistory is the failure timeline of a test across the baselines.
+type TestHistory struct {
+	Name string `json:"name"`
+
+	// Plugins are the plugins the test failed.
+	Plugins []string `json:"plugins"`
+
+	// Failures is the number of baselines the test failed, from Total baselines.
+	Failures int `json:"failures"`
+	Total    int `json:"total"`
+
+	// Rank is the position of the test in the chronic failures ranking, starting at 1.
+	Rank int `json:"rank"`
+
+	// Timeline is the result of the test in each baseline, from the oldest to the
+	// newest, F: failed, .: not failed.
+	Timeline string `json:"timeline"`
+
+	// LastFailure is the name of the most recent baseline the test failed.
+	LastFailure string `json:"lastFailure"`
+}
+
+// Share returns the share of the baselines the test failed.
+func (th *TestHistory) Share() float64 {
+	if th.Total == 0 {
+		return 0
+	}
+	return float64(th.Failures) / float64(th.Total)
+}
+
+// String returns the failure frequency and the chronic rank. Example: 4/10 #3
+func (th *TestHistory) String() string {
+	return fmt.Sprintf("%d/%d #%d", th.Failures, th.Total, th.Rank)
+}
+
+// FailureHistory is the failure history of the tests across the baselines of a release
+// and platform type, built from the filtered failures of each baseline summary.
+type FailureHistory struct {
+	Release  string `json:"release"`
+	Platform string `json:"platform"`
+
+	// Baselines are the names of the baselines, from the oldest to the newest.
+	Baselines []string `json:"baselines"`
+
+	// Tests is the history of the tests failed in at least one baseline.
+	Tests map[string]*TestHistory `json:"tests"`
+}
+
+// NewFailureHistory creates the failure history from the failures of each baseline,
+// sorted from the oldest to the newest. Failures are indexed by plugin name.
+func NewFailureHistory(release, platform string, baselines []string, failures []map[string][]string) *FailureHistory {
+	fh := &FailureHistory{
+		Release:   release,
+		Platform:  platform,
+		Baselines: baselines,
+		Tests:     make(map[string]*TestHistory),
+	}
+	timelines := make(map[string][]string)
+	for idx := range baselines {
+		failed := make(map[string]struct{})
+		for pluginName, tests := range failures[idx] {
+			for _, name := range tests {
+				th, ok := fh.Tests[name]
+				if !ok {
+					th = &TestHistory{Name: name, Total: len(baselines)}
+					fh.Tests[name] = th
+					timelines[name] = make([]string, len(baselines))
+					for i := range timelines[name] {
+						timelines[name][i] = historyPassed
+					}
+				}
+				if !contains(th.Plugins, pluginName) {
+					th.Plugins = append(th.Plugins, pluginName)
+					sort.Strings(th.Plugins)
+				}
+				if _, ok := failed[name]; ok {
+					continue
+				}
+				failed[name] = struct{}{}
+				th.Failures++
+				th.LastFailure = baselines[idx]
+				timelines[name][idx] = historyFailed
+			}
+		}
+	}
+	for name, timeline := range timelines {
+		fh.Tests[name].Timeline = strings.Join(timeline, "")
+	}
+	for idx, th := range fh.Chronic(0) {
+		th.Rank = idx + 1
+	}
+	return fh
+}
+
+func contains(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the history of the test, or nil when the test never failed in the baselines.
+func (fh *FailureHistory) Get(name string) *TestHistory {
+	return fh.Tests[name]
+}
+
+// Chronic returns up to 'limit' tests that fail most often, sorted by the number of
+// failures, and the most recent failure. All tests are returned when limit is zero.
+func (fh *FailureHistory) Chronic(limit int) []*TestHistory {
+	tests := make([]*TestHistory, 0, len(fh.Tests))
+	for _, th := range fh.Tests {
+		tests = append(tests, th)
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].Failures != tests[j].Failures {
+			return tests[i].Failures > tests[j].Failures
+		}
+		if tests[i].Timeline != tests[j].Timeline {
+			// the most recent failures first: timeline is from the oldest to the
+			// newest, and historyFailed sorts after historyPassed.
+			return reverse(tests[i].Timeline) > reverse(tests[j].Timeline)
+		}
+		return tests[i].Name < tests[j].Name
+	})
+	if limit > 0 && len(tests) > limit {
+		tests = tests[:limit]
+	}
+	return tests
+}
+
+// reverse returns the timeline from the newest to the oldest.
+func reverse(timeline string) string {
+	r := []byte(timeline)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// Match returns the history of the tests matching the name or the regular expression,
+// sorted by the chronic rank. Tests never failed are not in the history.
+func (fh *FailureHistory) Match(expr string) ([]*TestHistory, error) {
+	if th := fh.Get(expr); th != nil {
+		return []*TestHistory{th}, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test name expression %q: %w", expr, err)
+	}
+	tests := []*TestHistory{}
+	for _, th := range fh.Chronic(0) {
+		if re.MatchString(th.Name) {
+			tests = append(tests, th)
+		}
+	}
+	return tests, nil
+}
+
+// GetFailureHistory builds the failure history of the tests across the most recent
+// 'count' baselines of the release and platform type listed in the index, reading
+// the filtered failures of the plugins from each summary. All baselines are used
+// when count is zero.
+func (brs *BaselineConfig) GetFailureHistory(release, platform string, count int, plugins []string) (*FailureHistory, error) {
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the baseline index: %w", err)
+	}
+	items := index.GetRecentByPlatform(release, platform, count)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no baselines found for release %q and platform %q", release, platform)
+	}
+
+	baselines := []string{}
+	failures := []map[string][]string{}
+	// items are sorted from the newest to the oldest.
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		body, err := brs.GetSummaryByName(item.Name)
+		if errors.Is(err, ErrIntegrity) {
+			return nil, err
+		}
+		if err != nil {
+			log.Warnf("unable to read baseline summary %s, skipping: %v", item.Name, err)
+			continue
+		}
+		bd := &BaselineData{}
+		bd.SetRawData(body)
+		bd.SetName(item.Name)
+		pluginFailures := make(map[string][]string, len(plugins))
+		var parseErr error
+		for _, pluginName := range plugins {
+			tests, err := bd.GetPriorityFailuresFromPlugin(pluginName)
+			if err != nil {
+				parseErr = err
+				break
+			}
+			pluginFailures[pluginName] = tests
+		}
+		// baselines which failures can't be parsed are not counted in the history.
+		if parseErr != nil {
+			log.Warnf("unable to read failures from baseline %s, skipping: %v", item.Name, parseErr)
+			continue
+		}
+		baselines = append(baselines, item.Name)
+		failures = append(failures, pluginFailures)
+	}
+	if len(baselines) == 0 {
+		return nil, fmt.Errorf("unable to read the baselines for release %q and platform %q", release, platform)
+	}
+	return NewFailureHistory(release, platform, baselines, failures), nil
+}
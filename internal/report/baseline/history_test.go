@@ -0,0 +1,91 @@
+package baseline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFailureHistory(t *testing.T) {
+	fh := NewFailureHistory("4.15", "None", []string{"b1", "b2", "b3", "b4"}, []map[string][]string{
+		{"p1": {"t1", "t2"}},
+		{"p1": {"t1"}, "p2": {"t1", "t3"}},
+		{"p1": {"t2"}},
+		{"p1": {"t1", "t3"}},
+	})
+	t1 := fh.Get("t1")
+	assert.Equal(t, &TestHistory{Name: "t1", Plugins: []string{"p1", "p2"}, Failures: 3, Total: 4, Rank: 1, Timeline: "FF.F", LastFailure: "b4"}, t1)
+	assert.Equal(t, "3/4 #1", t1.String())
+	assert.Equal(t, 0.75, t1.Share())
+	assert.Nil(t, fh.Get("t4"))
+
+	// t3 failed more recently than t2
+	names := func(tests []*TestHistory) []string {
+		res := []string{}
+		for _, th := range tests {
+			res = append(res, th.Name)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"t1", "t3", "t2"}, names(fh.Chronic(0)))
+	assert.Equal(t, []string{"t1", "t3"}, names(fh.Chronic(2)))
+	assert.Equal(t, "F.F.", fh.Get("t2").Timeline)
+	assert.Equal(t, 3, fh.Get("t2").Rank)
+
+	match, err := fh.Match("t2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t2"}, names(match))
+	match, err = fh.Match("t[23]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t3", "t2"}, names(match))
+	_, err = fh.Match("t[")
+	assert.Error(t, err)
+}
+
+func TestGetFailureHistory(t *testing.T) {
+	store, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+	brs := &BaselineConfig{store: store}
+
+	prefix := apiKeyPrefix + "/result/summary/"
+	summary := func(date string, failures ...string) string {
+		items := []string{}
+		for _, f := range failures {
+			items = append(items, `{"name":"`+f+`"}`)
+		}
+		return `{"setup":{"api":{"openshiftRelease":"4.15","platformType":"None","executionDate":"` + date + `"}},` +
+			`"provider":{"plugins":{"p1":{"id":"p1","failedFiltered":[` + strings.Join(items, ",") + `]}}}}`
+	}
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240101000000.json", strings.NewReader(summary("20240101000000", "t1")), nil))
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240201000000.json", strings.NewReader(summary("20240201000000", "t1", "t2")), nil))
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240301000000.json", strings.NewReader(summary("20240301000000")), nil))
+	_, err = brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+
+	fh, err := brs.GetFailureHistory("4.15", "None", 0, []string{"p1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4.15_None_20240101000000", "4.15_None_20240201000000", "4.15_None_20240301000000"}, fh.Baselines)
+	assert.Equal(t, "FF.", fh.Get("t1").Timeline)
+	assert.Equal(t, ".F.", fh.Get("t2").Timeline)
+
+	fh, err = brs.GetFailureHistory("4.15", "None", 2, []string{"p1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "F.", fh.Get("t1").Timeline)
+
+	// summaries with only failedPriority are read, summaries without provider are skipped.
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240401000000.json", strings.NewReader(
+		`{"setup":{"api":{"openshiftRelease":"4.15","platformType":"None","executionDate":"20240401000000"}},`+
+			`"provider":{"plugins":{"p1":{"id":"p1","failedPriority":[{"name":"t2"}]}}}}`), nil))
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240501000000.json", strings.NewReader(
+		`{"setup":{"api":{"openshiftRelease":"4.15","platformType":"None","executionDate":"20240501000000"}}}`), nil))
+	_, err = brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+	fh, err = brs.GetFailureHistory("4.15", "None", 0, []string{"p1"})
+	assert.NoError(t, err)
+	assert.Len(t, fh.Baselines, 4)
+	assert.Equal(t, ".F.F", fh.Get("t2").Timeline)
+
+	_, err = brs.GetFailureHistory("4.16", "None", 0, []string{"p1"})
+	assert.Error(t, err)
+}
@@ -1,15 +1,14 @@
 package baseline
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
-	"io"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/cloudfront"
-	"github.com/aws/aws-sdk-go/service/s3"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -22,9 +21,14 @@ type baselineIndexItem struct {
 	PlatformType     string                 `json:"platform_type"`
 	Status           string                 `json:"status"`
 	Size             string                 `json:"size"`
+	ETag             string                 `json:"etag,omitempty"`
+	SHA256           string                 `json:"sha256,omitempty"`
+	Signature        string                 `json:"signature,omitempty"`
+	Identity         string                 `json:"identity,omitempty"`
 	IsLatest         bool                   `json:"is_latest"`
 	Tags             map[string]interface{} `json:"tags"`
 }
+
 type baselineIndex struct {
 	LastUpdate string                        `json:"date"`
 	Status     string                        `json:"status"`
@@ -32,183 +36,423 @@ type baselineIndex struct {
 	Latest     map[string]*baselineIndexItem `json:"latest"`
 }
 
-// CreateBaselineIndex list all object from S3 Bucket, extract metadata,
-// and calculate the latest by release and platform type, creating a index.json
-// object.
-func (brs *BaselineConfig) CreateBaselineIndex() error {
-	svcS3, _, err := brs.createS3Clients()
+// GetRecentByPlatform returns up to 'count' most recent results of the release and
+// platform type, sorted by date from newest to oldest.
+func (bi *baselineIndex) GetRecentByPlatform(ocpRelease, platformType string, count int) []*baselineIndexItem {
+	items := []*baselineIndexItem{}
+	for _, res := range bi.Results {
+		if res.OpenShiftRelease == ocpRelease && res.PlatformType == platformType {
+			items = append(items, res)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Date == items[j].Date {
+			return items[i].Name > items[j].Name
+		}
+		return items[i].Date > items[j].Date
+	})
+	if count > 0 && len(items) > count {
+		items = items[:count]
+	}
+	return items
+}
+
+// summaryKeyPattern is the pattern of the summary object names: {ocpVersion}_{platformType}_{timestamp}.json
+var summaryKeyPattern = regexp.MustCompile(`^(\d+\.\d+)_([A-Za-z0-9]+)_(\d+)\.json$`)
+
+// setupTagKeys are the keys of the summary tags (setup.api), used to restore the
+// tags from the object metadata which keys are lower case.
+var setupTagKeys = []string{
+	"dataPath", "summaryArchive", "uuid", "executionDate", "openshiftVersion",
+	"openshiftRelease", "platformType", "providerName", "infraTopology", "workflow",
+	"objectArtifact", "objectSummary", "archiveHash", "identity", "acceptance", "acceptanceWarnings",
+	TagPinned, TagDeprecated,
+}
+
+// IndexerOptions are the options of the baseline indexer.
+type IndexerOptions struct {
+	// Full ignores the existing index, reading all the summaries.
+	Full bool
+
+	// DryRun builds the index and checks the consistency without writing to the store.
+	DryRun bool
+
+	// Refresh are the object keys to read even when not changed, used when the object
+	// metadata has been changed (lifecycle tags).
+	Refresh []string
+
+	// SigningKey signs the hash of the summaries (detached signature) recorded in
+	// the index, when set. Results without signature are signed.
+	SigningKey ed25519.PrivateKey
+}
+
+// IndexReport is the result of the indexer, with the changes in the index and
+// the consistency check of the store.
+type IndexReport struct {
+	Added     []string `json:"added,omitempty"`
+	Updated   []string `json:"updated,omitempty"`
+	Unchanged []string `json:"unchanged,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+
+	// Malformed are the object keys not following the summary name format.
+	Malformed []string `json:"malformed,omitempty"`
+
+	// Orphaned are the objects without a reference: latest summaries without
+	// results, archives without summaries, and summaries without archives.
+	Orphaned []string `json:"orphaned,omitempty"`
+}
+
+// HasChanges returns true when the index has been changed.
+func (ir *IndexReport) HasChanges() bool {
+	return len(ir.Added) > 0 || len(ir.Updated) > 0 || len(ir.Removed) > 0
+}
+
+// tagString returns the tag value as string.
+func tagString(tags map[string]interface{}, key string) string {
+	if v, ok := tags[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// tagsFromMetadata restores the summary tags (setup.api) from the object metadata,
+// returning nil when the metadata does not carry the required tags.
+func tagsFromMetadata(meta map[string]string) map[string]interface{} {
+	tags := make(map[string]interface{}, len(meta))
+	for k, v := range meta {
+		tags[k] = v
+	}
+	for _, key := range setupTagKeys {
+		lk := strings.ToLower(key)
+		if v, ok := meta[lk]; ok && lk != key {
+			delete(tags, lk)
+			tags[key] = v
+		}
+	}
+	for _, key := range []string{"openshiftRelease", "platformType", "executionDate"} {
+		if tagString(tags, key) == "" {
+			return nil
+		}
+	}
+	return tags
+}
+
+// newIndexItem creates the index item of the summary object, the name must follow
+// the format {ocpVersion}_{platformType}_{timestamp}.json.
+func newIndexItem(obj *StoreObject, tags map[string]interface{}) *baselineIndexItem {
+	name := obj.Key[strings.LastIndex(obj.Key, "/")+1:]
+	match := summaryKeyPattern.FindStringSubmatch(name)
+	res := &baselineIndexItem{
+		Date:             match[3],
+		Name:             strings.TrimSuffix(name, ".json"),
+		Path:             obj.Key,
+		Size:             fmt.Sprintf("%d", obj.Size),
+		ETag:             obj.ETag,
+		OpenShiftRelease: match[1],
+		PlatformType:     match[2],
+		Identity:         tagString(tags, "identity"),
+		Tags:             tags,
+	}
+	if v := tagString(tags, "openshiftRelease"); v != "" {
+		res.OpenShiftRelease = v
+	} else {
+		log.Warnf("missing openshiftRelease tag in metadata, extracting from name: %v", res.OpenShiftRelease)
+	}
+	if v := tagString(tags, "platformType"); v != "" {
+		res.PlatformType = v
+	} else {
+		log.Warnf("missing platformType tag in metadata, extracting from name: %v", res.PlatformType)
+	}
+	if v := tagString(tags, "executionDate"); v != "" {
+		res.Date = v
+	} else {
+		log.Warnf("missing executionDate tag in metadata, extracting from name: %v", res.Date)
+	}
+	return res
+}
+
+// IsPinned returns true when the result is pinned (lifecycle tag), pinned results
+// are preferred as latest and never pruned.
+func (bi *baselineIndexItem) IsPinned() bool {
+	return tagString(bi.Tags, TagPinned) == "true"
+}
+
+// IsDeprecated returns true when the result is deprecated (lifecycle tag), deprecated
+// results are never picked as latest.
+func (bi *baselineIndexItem) IsDeprecated() bool {
+	return tagString(bi.Tags, TagDeprecated) != ""
+}
+
+// FindByIdentity returns the results with the baseline identity.
+func (bi *baselineIndex) FindByIdentity(identity string) []*baselineIndexItem {
+	items := []*baselineIndexItem{}
+	for _, res := range bi.Results {
+		if res.Identity != "" && res.Identity == identity {
+			items = append(items, res)
+		}
+	}
+	return items
+}
+
+// calculateLatest sets the latest result by release and platform type. Pinned
+// results are preferred, even when older than other results: pinning a result
+// selects it as the reference baseline until it is unpinned. Deprecated results
+// are never picked as latest.
+func (bi *baselineIndex) calculateLatest() {
+	bi.Latest = make(map[string]*baselineIndexItem)
+	for _, res := range bi.Results {
+		res.IsLatest = false
+		if res.IsDeprecated() {
+			continue
+		}
+		latestIndexKey := fmt.Sprintf("%s_%s", res.OpenShiftRelease, res.PlatformType)
+		existing, ok := bi.Latest[latestIndexKey]
+		if !ok || isNewerLatest(res, existing) {
+			bi.Latest[latestIndexKey] = res
+		}
+	}
+	for _, res := range bi.Latest {
+		res.IsLatest = true
+	}
+}
+
+// isNewerLatest returns true when the result must replace the existing latest.
+func isNewerLatest(res, existing *baselineIndexItem) bool {
+	if res.IsPinned() != existing.IsPinned() {
+		return res.IsPinned()
+	}
+	if existing.Date == res.Date {
+		return existing.Name < res.Name
+	}
+	return existing.Date < res.Date
+}
+
+// readIndex reads the index from the store, returning an empty index when not found.
+func (brs *BaselineConfig) readIndex() *baselineIndex {
+	index := &baselineIndex{}
+	data, err := brs.store.Get(indexObjectKey)
+	if err != nil {
+		log.Infof("Index not found in the store, creating a new index: %v", err)
+		return index
+	}
+	if err := json.Unmarshal(data, index); err != nil {
+		log.Warnf("Unable to parse the existing index, creating a new index: %v", err)
+		return &baselineIndex{}
+	}
+	return index
+}
+
+// readSummaryTags reads the tags of the summary object, from the object metadata
+// when available, otherwise from the summary data (setup.api). The data is read
+// from the store when not provided.
+func (brs *BaselineConfig) readSummaryTags(key string, data []byte) (map[string]interface{}, error) {
+	meta, err := brs.store.GetMetadata(key)
 	if err != nil {
-		return fmt.Errorf("failed to create S3 client and validate bucket: %w", err)
+		log.Debugf("unable to read metadata of %s: %v", key, err)
+	}
+	if tags := tagsFromMetadata(meta); tags != nil {
+		return tags, nil
+	}
+	log.Debugf("missing tags in the metadata of %s, reading the summary data", key)
+	if data == nil {
+		data, err = brs.store.Get(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	bd := &BaselineData{}
+	bd.SetRawData(data)
+	return bd.GetSetupTags()
+}
+
+// CreateBaselineIndex list all object from the store, and calculate the latest by
+// release and platform type, creating a index.json object.
+// The index is incremental: the existing index is reused, and only the summaries
+// which ETag or size changed are read, recording the SHA-256 of the summary data. The summary tags are read from the object
+// metadata, falling back to the summary data (setup.api) when not available.
+func (brs *BaselineConfig) CreateBaselineIndex(opts *IndexerOptions) (*IndexReport, error) {
+	if opts == nil {
+		opts = &IndexerOptions{}
+	}
+	if !opts.DryRun {
+		if err := brs.checkWriteAllowed(); err != nil {
+			return nil, err
+		}
 	}
 
-	// List all the objects in the bucket and create index.
-	objects, err := ListObjects(svcS3, brs.bucketRegion, brs.bucketName, "api/v0/result/summary/")
+	// List all the objects in the store and create index.
+	objects, err := brs.store.List(apiKeyPrefix + "/result/summary/")
+	if err != nil {
+		return nil, err
+	}
+	uploads, err := brs.store.List("uploads/")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	index := baselineIndex{
-		LastUpdate: time.Now().Format(time.RFC3339),
-		Latest:     make(map[string]*baselineIndexItem),
+	current := &baselineIndex{}
+	if !opts.Full {
+		current = brs.readIndex()
+	}
+	currentItems := make(map[string]*baselineIndexItem, len(current.Results))
+	for _, res := range current.Results {
+		currentItems[res.Path] = res
 	}
+	refresh := make(map[string]struct{}, len(opts.Refresh))
+	for _, key := range opts.Refresh {
+		refresh[key] = struct{}{}
+	}
+
+	report := &IndexReport{}
+	index := &baselineIndex{LastUpdate: time.Now().Format(time.RFC3339)}
+	latestObjects := make(map[string]*StoreObject)
 	// calculate the index for each object (summary)
 	for _, obj := range objects {
+		name := obj.Key[strings.LastIndex(obj.Key, "/")+1:]
+		if obj.Key == indexObjectKey {
+			continue
+		}
+		if strings.HasSuffix(name, "_latest.json") {
+			latestObjects[strings.TrimSuffix(name, "_latest.json")] = obj
+			continue
+		}
 		// Keys must have the following format: {ocpVersion}_{platformType}_{timestamp}.json
-		objectKey := *obj.Key
-
-		name := objectKey[strings.LastIndex(objectKey, "/")+1:]
-		if name == "index.json" {
+		if !summaryKeyPattern.MatchString(name) {
+			log.Warnf("Skipping malformed summary key %s: want {ocpVersion}_{platformType}_{timestamp}.json", obj.Key)
+			report.Malformed = append(report.Malformed, obj.Key)
 			continue
 		}
 
-		// read the object to extract metadata/tags from 'setup.api'
-		objReader, err := svcS3.GetObject(&s3.GetObjectInput{
-			Bucket: aws.String(brs.bucketName),
-			Key:    aws.String(objectKey),
-		})
-		if err != nil {
-			log.Errorf("failed to get object %s: %v", objectKey, err)
+		existing, ok := currentItems[obj.Key]
+		delete(currentItems, obj.Key)
+		_, forceRefresh := refresh[obj.Key]
+		// results indexed without hash are read to record the hash.
+		if ok && !forceRefresh && existing.ETag != "" && existing.SHA256 != "" && existing.ETag == obj.ETag && existing.Size == fmt.Sprintf("%d", obj.Size) {
+			if opts.SigningKey != nil && existing.Signature == "" {
+				existing.Signature = signSummary(opts.SigningKey, existing.Name, existing.SHA256)
+				report.Updated = append(report.Updated, obj.Key)
+			} else {
+				report.Unchanged = append(report.Unchanged, obj.Key)
+			}
+			index.Results = append(index.Results, existing)
 			continue
 		}
 
-		defer objReader.Body.Close()
-		bd := &BaselineData{}
-		body, err := io.ReadAll(objReader.Body)
+		log.Infof("Processing summary object: %s", name)
+		body, err := brs.store.Get(obj.Key)
 		if err != nil {
-			log.Errorf("failed to read object data %s: %v", objectKey, err)
+			log.Errorf("failed to read summary %s, skipping: %v", obj.Key, err)
+			report.Malformed = append(report.Malformed, obj.Key)
 			continue
 		}
-
-		bd.SetRawData(body)
-		tags, err := bd.GetSetupTags()
+		tags, err := brs.readSummaryTags(obj.Key, body)
 		if err != nil {
-			log.Errorf("failed to deserialize tags/metadata from summary data: %v", err)
+			log.Errorf("failed to read tags of summary %s, skipping: %v", obj.Key, err)
+			report.Malformed = append(report.Malformed, obj.Key)
+			continue
 		}
-
-		log.Infof("Processing summary object: %s", name)
-		log.Infof("Processing metadata: %v", tags)
-		openShiftRelease := strings.Split(name, "_")[0]
-		if _, ok := tags["openshiftRelease"]; ok {
-			openShiftRelease = tags["openshiftRelease"].(string)
+		log.Debugf("Processing metadata: %v", tags)
+		if ok {
+			report.Updated = append(report.Updated, obj.Key)
 		} else {
-			log.Warnf("missing openshiftRelease tag in metadata, extracting from name: %v", openShiftRelease)
+			report.Added = append(report.Added, obj.Key)
 		}
-
-		platformType := strings.Split(name, "_")[1]
-		if _, ok := tags["platformType"]; ok {
-			platformType = tags["platformType"].(string)
-		} else {
-			log.Warnf("missing platformType tag in metadata, extracting from name: %v", platformType)
+		item := newIndexItem(obj, tags)
+		item.SHA256 = SummaryDigest(body)
+		if opts.SigningKey != nil {
+			item.Signature = signSummary(opts.SigningKey, item.Name, item.SHA256)
 		}
+		index.Results = append(index.Results, item)
+	}
+	for path := range currentItems {
+		report.Removed = append(report.Removed, path)
+	}
+	sort.Strings(report.Removed)
+	index.calculateLatest()
 
-		executionDate := strings.Split(name, "_")[2]
-		if _, ok := tags["executionDate"]; ok {
-			executionDate = tags["executionDate"].(string)
-		} else {
-			log.Warnf("missing executionDate tag in metadata, extracting from name: %v", executionDate)
-		}
-
-		// Creating summary item for baseline result
-		res := &baselineIndexItem{
-			Date:             executionDate,
-			Name:             strings.Split(name, ".json")[0],
-			Path:             objectKey,
-			Size:             fmt.Sprintf("%d", *obj.Size),
-			OpenShiftRelease: openShiftRelease,
-			PlatformType:     platformType,
-			Tags:             tags,
-		}
-		// spew.Dump(res)
-		index.Results = append(index.Results, res)
-		latestIndexKey := fmt.Sprintf("%s_%s", openShiftRelease, platformType)
-		existing, ok := index.Latest[latestIndexKey]
-		if !ok {
-			res.IsLatest = true
-			index.Latest[latestIndexKey] = res
-		} else {
-			if existing.Date < res.Date {
-				existing.IsLatest = false
-				res.IsLatest = true
-				index.Latest[latestIndexKey] = res
-			}
+	// Consistency check: latest summaries without results, and archives without summaries.
+	for kLatest, obj := range latestObjects {
+		if _, ok := index.Latest[kLatest]; !ok {
+			report.Orphaned = append(report.Orphaned, obj.Key)
+		}
+	}
+	artifacts := make(map[string]struct{}, len(index.Results))
+	for _, res := range index.Results {
+		artifact := tagString(res.Tags, "objectArtifact")
+		if artifact == "" {
+			continue
+		}
+		artifacts[artifact] = struct{}{}
+	}
+	uploadKeys := make(map[string]struct{}, len(uploads))
+	for _, obj := range uploads {
+		uploadKeys[obj.Key] = struct{}{}
+		if _, ok := artifacts[obj.Key]; !ok {
+			report.Orphaned = append(report.Orphaned, obj.Key)
+		}
+	}
+	for _, res := range index.Results {
+		artifact := tagString(res.Tags, "objectArtifact")
+		if _, ok := uploadKeys[artifact]; artifact != "" && !ok {
+			report.Orphaned = append(report.Orphaned, res.Path)
 		}
 	}
+	sort.Strings(report.Orphaned)
 
-	// Copy latest to respective path under /<version>_<platform>_latest.json
+	if opts.DryRun {
+		log.Warnf("DRY-RUN mode: skipping index update in the store %s", brs.store)
+		return report, nil
+	}
+
+	// Copy latest to respective path under /<version>_<platform>_latest.json,
+	// when it has been changed, removing the latest without results (deprecated or pruned).
+	latestChanged := false
+	for kLatest, obj := range latestObjects {
+		if _, ok := index.Latest[kLatest]; ok {
+			continue
+		}
+		latestChanged = true
+		log.Infof("Removing latest object without results %q", obj.Key)
+		if err := brs.store.Delete(obj.Key); err != nil {
+			log.Errorf("Couldn't remove latest object %s: %v", obj.Key, err)
+		}
+	}
 	for kLatest, latest := range index.Latest {
-		latestObjectKey := fmt.Sprintf("api/v0/result/summary/%s_latest.json", kLatest)
+		if prev, ok := current.Latest[kLatest]; ok && !opts.Full && prev.Path == latest.Path && prev.ETag == latest.ETag {
+			if _, exists := latestObjects[kLatest]; exists {
+				continue
+			}
+		}
+		latestChanged = true
+		latestObjectKey := fmt.Sprintf("%s/result/summary/%s_latest.json", apiKeyPrefix, kLatest)
 		log.Infof("Creating latest object for %q to %q", kLatest, latestObjectKey)
-		_, err := svcS3.CopyObject(&s3.CopyObjectInput{
-			Bucket:     aws.String(brs.bucketName),
-			CopySource: aws.String(fmt.Sprintf("%v/%v", brs.bucketName, latest.Path)),
-			Key:        aws.String(latestObjectKey),
-		})
-		if err != nil {
+		if err := brs.store.Copy(latest.Path, latestObjectKey); err != nil {
 			log.Errorf("Couldn't create latest object %s: %v", kLatest, err)
 		}
 	}
+	if !report.HasChanges() && !latestChanged && len(current.Results) > 0 {
+		log.Info("Index is up to date, skipping index update.")
+		return report, nil
+	}
 
-	// Save the new index to the bucket.
+	// Save the new index to the store.
 	indexJSON, err := json.Marshal(index)
 	if err != nil {
-		return fmt.Errorf("unable to save index to json: %w", err)
+		return nil, fmt.Errorf("unable to save index to json: %w", err)
 	}
-
-	// Save the index to the bucket
-	_, err = svcS3.PutObject(&s3.PutObjectInput{
-		Bucket: aws.String(brs.bucketName),
-		Key:    aws.String(indexObjectKey),
-		Body:   strings.NewReader(string(indexJSON)),
-	})
-	if err != nil {
-		return fmt.Errorf("failed to upload index to bucket: %w", err)
+	if err := brs.store.Put(indexObjectKey, strings.NewReader(string(indexJSON)), nil); err != nil {
+		return nil, fmt.Errorf("failed to upload index to store: %w", err)
 	}
+	brs.index = nil
 
-	// Expire cache from cloudfront distribution
-	svcCloudfront, err := createCloudFrontClient(brs.bucketRegion)
-	if err != nil {
-		return fmt.Errorf("failed to create cloudfront client: %w", err)
-	}
+	// Expire cache from the results API (CloudFront distribution)
 	invalidationPathsStr := []string{
 		"/result/summary/index.json",
 		"/result/summary/*_latest.json",
 	}
 	log.Infof("Creating cache invalidation for %v", strings.Join(invalidationPathsStr, " "))
-	var invalidationPaths []*string
-	for _, path := range invalidationPathsStr {
-		invalidationPaths = append(invalidationPaths, aws.String(path))
-	}
-	_, err = svcCloudfront.CreateInvalidation(&cloudfront.CreateInvalidationInput{
-		DistributionId: aws.String(brs.cloudfrontDistributionID),
-		InvalidationBatch: &cloudfront.InvalidationBatch{
-			CallerReference: aws.String(time.Now().Format(time.RFC3339)),
-			Paths: &cloudfront.Paths{
-				Quantity: aws.Int64(int64(len(invalidationPaths))),
-				Items:    invalidationPaths,
-			},
-		},
-	})
-	if err != nil {
-
-		log.Warnf("failed to create cache invalidation: %v", err)
-		fmt.Printf(`Index updated. Run the following command to invalidate index.cache:
-aws cloudfront create-invalidation \
-	--distribution-id %s \
-	--paths %s`, brs.cloudfrontDistributionID, strings.Join(invalidationPathsStr, " "))
-		fmt.Println()
-	}
-	return nil
-}
-
-// ListObjects lists all the objects in the bucket.
-func ListObjects(svc *s3.S3, bucketRegion, bucketName, path string) ([]*s3.Object, error) {
-	input := &s3.ListObjectsInput{
-		Bucket: aws.String(bucketName),
-		Prefix: aws.String(path),
-	}
-	resp, err := svc.ListObjects(input)
-	if err != nil {
-		return nil, err
+	if err := brs.store.Invalidate(invalidationPathsStr); err != nil {
+		log.Warnf("Index updated. %v", err)
 	}
-	return resp.Contents, nil
+	return report, nil
 }
@@ -0,0 +1,121 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecentByPlatform(t *testing.T) {
+	index := &baselineIndex{
+		Results: []*baselineIndexItem{
+			{Name: "4.15_None_20240101", Date: "20240101", OpenShiftRelease: "4.15", PlatformType: "None"},
+			{Name: "4.15_None_20240301", Date: "20240301", OpenShiftRelease: "4.15", PlatformType: "None"},
+			{Name: "4.15_AWS_20240401", Date: "20240401", OpenShiftRelease: "4.15", PlatformType: "AWS"},
+			{Name: "4.15_None_20240201", Date: "20240201", OpenShiftRelease: "4.15", PlatformType: "None"},
+			{Name: "4.16_None_20240501", Date: "20240501", OpenShiftRelease: "4.16", PlatformType: "None"},
+		},
+	}
+	names := func(items []*baselineIndexItem) []string {
+		res := []string{}
+		for _, item := range items {
+			res = append(res, item.Name)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"4.15_None_20240301", "4.15_None_20240201"}, names(index.GetRecentByPlatform("4.15", "None", 2)))
+	assert.Equal(t, []string{"4.15_None_20240301", "4.15_None_20240201", "4.15_None_20240101"}, names(index.GetRecentByPlatform("4.15", "None", 0)))
+	assert.Empty(t, index.GetRecentByPlatform("4.15", "External", 2))
+}
+
+// countingStore counts the objects read from the store.
+type countingStore struct {
+	BaselineStore
+	gets map[string]int
+}
+
+func (st *countingStore) Get(key string) ([]byte, error) {
+	st.gets[key]++
+	return st.BaselineStore.Get(key)
+}
+
+func TestTagsFromMetadata(t *testing.T) {
+	tags := tagsFromMetadata(map[string]string{
+		"openshiftrelease": "4.15",
+		"platformtype":     "None",
+		"executiondate":    "2024-01-01T00:00:00Z",
+		"custom":           "value",
+	})
+	assert.Equal(t, map[string]interface{}{
+		"openshiftRelease": "4.15",
+		"platformType":     "None",
+		"executionDate":    "2024-01-01T00:00:00Z",
+		"custom":           "value",
+	}, tags)
+	assert.Nil(t, tagsFromMetadata(map[string]string{"openshiftrelease": "4.15"}))
+	assert.Nil(t, tagsFromMetadata(nil))
+}
+
+func TestCreateBaselineIndex(t *testing.T) {
+	local, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+	store := &countingStore{BaselineStore: local, gets: map[string]int{}}
+	brs := &BaselineConfig{store: store}
+
+	prefix := apiKeyPrefix + "/result/summary/"
+	keyA := prefix + "4.15_None_20240101000000.json"
+	keyB := prefix + "4.15_None_20240201000000.json"
+	// summary with tags in the metadata
+	assert.NoError(t, local.Put(keyA, strings.NewReader(`{}`), map[string]string{
+		"openshiftRelease": "4.15",
+		"platformType":     "None",
+		"executionDate":    "2024-01-01T00:00:00Z",
+		"objectArtifact":   "uploads/a.tar.gz",
+	}))
+	assert.NoError(t, local.Put("uploads/a.tar.gz", strings.NewReader("a"), nil))
+	// summary without metadata, tags are read from setup.api
+	summaryB := `{"setup":{"api":{"openshiftRelease":"4.15","platformType":"None","executionDate":"2024-02-01T00:00:00Z"}}}`
+	assert.NoError(t, local.Put(keyB, strings.NewReader(summaryB), nil))
+	assert.NoError(t, local.Put(prefix+"malformed.json", strings.NewReader(`{}`), nil))
+	assert.NoError(t, local.Put(prefix+"4.14_AWS_latest.json", strings.NewReader(`{}`), nil))
+	assert.NoError(t, local.Put("uploads/orphan.tar.gz", strings.NewReader("o"), nil))
+
+	report, err := brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{keyA, keyB}, report.Added)
+	assert.Equal(t, []string{prefix + "malformed.json"}, report.Malformed)
+	assert.Equal(t, []string{prefix + "4.14_AWS_latest.json", "uploads/orphan.tar.gz"}, report.Orphaned)
+	assert.Equal(t, 1, store.gets[keyA], "summaries must be read once to record the hash")
+	assert.Equal(t, 1, store.gets[keyB])
+
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.Len(t, index.Results, 2)
+	assert.Equal(t, "4.15_None_20240201000000", index.Latest["4.15_None"].Name)
+	assert.Equal(t, SummaryDigest([]byte(summaryB)), index.Latest["4.15_None"].SHA256)
+	latest, err := local.Get(prefix + "4.15_None_latest.json")
+	assert.NoError(t, err)
+	assert.Equal(t, summaryB, string(latest))
+
+	// incremental: only changed summaries are read
+	assert.NoError(t, local.Put(keyB, strings.NewReader(strings.Replace(summaryB, "None", "External", 1)), nil))
+	report, err = brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{keyA}, report.Unchanged)
+	assert.Equal(t, []string{keyB}, report.Updated)
+	assert.Equal(t, 2, store.gets[keyB])
+
+	index, err = brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.Equal(t, "4.15_None_20240101000000", index.Latest["4.15_None"].Name)
+	assert.Equal(t, "4.15_None_20240201000000", index.Latest["4.15_External"].Name)
+
+	// removed summaries are dropped from the index
+	assert.NoError(t, os.Remove(filepath.Join(local.(*localStore).Dir(), filepath.FromSlash(keyA))))
+	report, err = brs.CreateBaselineIndex(&IndexerOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{keyA}, report.Removed)
+}
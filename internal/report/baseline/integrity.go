@@ -0,0 +1,195 @@
+package baseline
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrIntegrity is returned when a summary downloaded from the store does not match
+// the hash, or the signature, recorded in the index.
+var ErrIntegrity = errors.New("baseline integrity check failed")
+
+// IntegrityOptions are the options to verify the summaries read from the store.
+type IntegrityOptions struct {
+	// WarnOnly logs the integrity failures (hash mismatch or invalid signature)
+	// instead of failing.
+	WarnOnly bool
+
+	// PublicKey verifies the detached signatures of the summaries, recorded in the
+	// index, when set. Summaries which can't be verified (not indexed, without hash
+	// or signature) are rejected when the key is set.
+	PublicKey ed25519.PublicKey
+}
+
+// SummaryDigest returns the SHA-256 (hex) of the summary data.
+func SummaryDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// signatureMessage is the message signed for a summary, binding the hash to the
+// summary name preventing the signature to be reused by other summaries.
+func signatureMessage(name, digest string) []byte {
+	return []byte(fmt.Sprintf("%s sha256:%s", name, digest))
+}
+
+// signSummary returns the detached signature (base64) of the summary hash.
+func signSummary(key ed25519.PrivateKey, name, digest string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, signatureMessage(name, digest)))
+}
+
+// verifySignature verifies the detached signature (base64) of the summary hash.
+func verifySignature(key ed25519.PublicKey, name, digest, signature string) error {
+	if signature == "" {
+		return fmt.Errorf("missing signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if !ed25519.Verify(key, signatureMessage(name, digest), sig) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// readPEM reads the first PEM block from the file.
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode PEM key file %s", path)
+	}
+	return block, nil
+}
+
+// LoadSigningKey reads the ed25519 private key (PEM, PKCS #8) used by the indexer to
+// sign the summaries. Example to create the keys:
+// openssl genpkey -algorithm ed25519 -out baseline.key
+// openssl pkey -in baseline.key -pubout -out baseline.pub
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key %s: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key %s: want ed25519", path)
+	}
+	return edKey, nil
+}
+
+// LoadVerifyKey reads the ed25519 public key (PEM, PKIX) used to verify the signatures.
+func LoadVerifyKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse public key %s: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key %s: want ed25519", path)
+	}
+	return edKey, nil
+}
+
+// SetIntegrityOptions sets the options to verify the summaries read from the store.
+func (brs *BaselineConfig) SetIntegrityOptions(opts *IntegrityOptions) {
+	brs.integrity = opts
+}
+
+// integrityIndex returns the index used to verify the summaries, read once.
+func (brs *BaselineConfig) integrityIndex() (*baselineIndex, error) {
+	if brs.index != nil {
+		return brs.index, nil
+	}
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	if err != nil {
+		return nil, err
+	}
+	brs.index = index
+	return index, nil
+}
+
+// findIndexItem returns the index item of the summary by name, including the
+// latest summaries ({release}_{platformType}_latest).
+func (bi *baselineIndex) findIndexItem(name string) *baselineIndexItem {
+	if strings.HasSuffix(name, "_latest") {
+		return bi.Latest[strings.TrimSuffix(name, "_latest")]
+	}
+	for _, res := range bi.Results {
+		if res.Name == name {
+			return res
+		}
+	}
+	return nil
+}
+
+// verifySummary verifies the summary data with the hash, and the signature when the
+// public key is set, recorded in the index. Summaries that can't be verified (index
+// not available, or summary not recorded in the index, or without hash, as in indexes
+// created before the hashes were introduced) are accepted as unverified with a warning,
+// unless the public key is set.
+func (brs *BaselineConfig) verifySummary(name string, data []byte) error {
+	opts := brs.integrity
+	if opts == nil {
+		opts = &IntegrityOptions{}
+	}
+	fail := func(format string, args ...interface{}) error {
+		err := fmt.Errorf("%w: summary %s: %s", ErrIntegrity, name, fmt.Sprintf(format, args...))
+		if opts.WarnOnly {
+			log.Warnf("%v (ignored by warn-only mode)", err)
+			return nil
+		}
+		return err
+	}
+	unverified := func(format string, args ...interface{}) error {
+		if opts.PublicKey != nil {
+			return fail(format, args...)
+		}
+		log.Warnf("summary %s is unverified: %s", name, fmt.Sprintf(format, args...))
+		return nil
+	}
+
+	index, err := brs.integrityIndex()
+	if err != nil {
+		return unverified("unable to read the index: %v", err)
+	}
+	item := index.findIndexItem(name)
+	if item == nil {
+		return unverified("summary not found in the index")
+	}
+	if item.SHA256 == "" {
+		return unverified("missing hash in the index, re-index the store to record it")
+	}
+	if digest := SummaryDigest(data); digest != item.SHA256 {
+		return fail("hash mismatch, want=%s, got=%s (%d bytes)", item.SHA256, digest, len(data))
+	}
+	if opts.PublicKey != nil {
+		if err := verifySignature(opts.PublicKey, item.Name, item.SHA256, item.Signature); err != nil {
+			return fail("%v", err)
+		}
+	}
+	log.Debugf("summary %s verified, sha256=%s", name, item.SHA256)
+	return nil
+}
@@ -0,0 +1,124 @@
+package baseline
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadKeys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	assert.NoError(t, err)
+	privPath, pubPath := filepath.Join(dir, "baseline.key"), filepath.Join(dir, "baseline.pub")
+	assert.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+	assert.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+
+	signingKey, err := LoadSigningKey(privPath)
+	assert.NoError(t, err)
+	assert.Equal(t, priv, signingKey)
+	verifyKey, err := LoadVerifyKey(pubPath)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, verifyKey)
+
+	_, err = LoadSigningKey(pubPath)
+	assert.Error(t, err)
+	_, err = LoadVerifyKey(filepath.Join(dir, "missing.pub"))
+	assert.Error(t, err)
+}
+
+func TestVerifySummary(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	store, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+	brs := &BaselineConfig{store: store}
+	prefix := apiKeyPrefix + "/result/summary/"
+	name := "4.15_None_20240101000000"
+	summary := `{"setup":{"api":{"openshiftRelease":"4.15","platformType":"None","executionDate":"20240101000000"}}}`
+	assert.NoError(t, store.Put(prefix+name+".json", strings.NewReader(summary), nil))
+
+	// unsigned index: the hash is verified, signatures required by the public key
+	_, err = brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+	data, err := brs.GetSummaryByName(name)
+	assert.NoError(t, err)
+	assert.Equal(t, summary, string(data))
+	assert.NoError(t, brs.GetLatestSummaryByPlatform("4.15", "None"))
+	brs.SetIntegrityOptions(&IntegrityOptions{PublicKey: pub})
+	_, err = brs.GetSummaryByName(name)
+	assert.True(t, errors.Is(err, ErrIntegrity), "unsigned summary must fail: %v", err)
+
+	// signed index: results without signature are signed without reading the summary
+	brs.SetIntegrityOptions(nil)
+	report, err := brs.CreateBaselineIndex(&IndexerOptions{SigningKey: priv})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{prefix + name + ".json"}, report.Updated)
+	brs.SetIntegrityOptions(&IntegrityOptions{PublicKey: pub})
+	_, err = brs.GetSummaryByName(name)
+	assert.NoError(t, err)
+	brs.SetIntegrityOptions(&IntegrityOptions{PublicKey: otherPub})
+	_, err = brs.GetSummaryByName(name)
+	assert.True(t, errors.Is(err, ErrIntegrity), "signature from other key must fail: %v", err)
+
+	// tampered summary, and latest summary, fail closed unless warn only
+	brs.SetIntegrityOptions(nil)
+	tampered := strings.Replace(summary, "None", "AWS", 1)
+	assert.NoError(t, store.Put(prefix+name+".json", strings.NewReader(tampered), nil))
+	assert.NoError(t, store.Put(prefix+"4.15_None_latest.json", strings.NewReader(tampered), nil))
+	_, err = brs.GetSummaryByName(name)
+	assert.True(t, errors.Is(err, ErrIntegrity), "tampered summary must fail: %v", err)
+	err = brs.GetLatestSummaryByPlatform("4.15", "None")
+	assert.True(t, errors.Is(err, ErrIntegrity), "tampered latest summary must fail: %v", err)
+	err = brs.GetRecentSummariesFromPlatformWithFallback("4.15", "None", 5)
+	assert.True(t, errors.Is(err, ErrIntegrity), "filter must fail closed: %v", err)
+
+	brs.SetIntegrityOptions(&IntegrityOptions{WarnOnly: true})
+	data, err = brs.GetSummaryByName(name)
+	assert.NoError(t, err)
+	assert.Equal(t, tampered, string(data))
+
+	// summaries not in the index can't be verified, accepted as unverified unless
+	// the public key is set
+	brs.SetIntegrityOptions(nil)
+	assert.NoError(t, store.Put(prefix+"4.16_None_20240101000000.json", strings.NewReader(summary), nil))
+	_, err = brs.GetSummaryByName("4.16_None_20240101000000")
+	assert.NoError(t, err)
+	brs.SetIntegrityOptions(&IntegrityOptions{PublicKey: pub})
+	_, err = brs.GetSummaryByName("4.16_None_20240101000000")
+	assert.True(t, errors.Is(err, ErrIntegrity), "summary not in the index must fail with public key: %v", err)
+
+	// summaries without hash (legacy index) are accepted as unverified
+	brs.SetIntegrityOptions(nil)
+	brs.index.findIndexItem(name).SHA256 = ""
+	_, err = brs.GetSummaryByName(name)
+	assert.NoError(t, err)
+	brs.SetIntegrityOptions(&IntegrityOptions{PublicKey: pub})
+	_, err = brs.GetSummaryByName(name)
+	assert.True(t, errors.Is(err, ErrIntegrity), "summary without hash must fail with public key: %v", err)
+
+	// index not available: the latest summary is used, unverified
+	brs.SetIntegrityOptions(nil)
+	brs.index = nil
+	assert.NoError(t, store.Delete(indexObjectKey))
+	_, err = brs.GetSummaryByName(name)
+	assert.NoError(t, err)
+	assert.NoError(t, brs.GetRecentSummariesFromPlatformWithFallback("4.15", "None", 5))
+	assert.Len(t, brs.buffers, 1)
+	assert.Equal(t, "4.15_None_latest", brs.buffers[0].GetName())
+}
@@ -0,0 +1,176 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Lifecycle tags of the baseline results, set in the summary object metadata.
+const (
+	// TagPinned is set to "true" on pinned results.
+	TagPinned = "pinned"
+
+	// TagDeprecated is set to the reason (or "true") on deprecated results.
+	TagDeprecated = "deprecated"
+)
+
+// PrunePolicy is the retention policy of baseline results. Pinned results, and the
+// latest result of each release and platform type, are always kept. Note that a
+// pinned result is preferred as latest, ahead of newer results (see calculateLatest).
+type PrunePolicy struct {
+	// KeepLast is the number of most recent results kept by release and platform
+	// type. Zero disables the policy.
+	KeepLast int
+
+	// OlderThan removes the results older than the duration. Zero disables the policy.
+	OlderThan time.Duration
+
+	// Release and Platform limit the results evaluated by the policy, when set.
+	Release  string
+	Platform string
+}
+
+// PruneItem is a result planned to be removed by the retention policy.
+type PruneItem struct {
+	Name     string
+	Path     string
+	Artifact string
+	Release  string
+	Platform string
+	Date     string
+	Reason   string
+}
+
+// parseResultDate parses the result date, from the executionDate tag (RFC3339),
+// or the timestamp of the summary name.
+func parseResultDate(date string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t, nil
+	}
+	return time.Parse("20060102150405", date)
+}
+
+// planPrune returns the results removed by the policy.
+func planPrune(index *baselineIndex, policy *PrunePolicy, now time.Time) []*PruneItem {
+	items := []*PruneItem{}
+	groups := make(map[string][]*baselineIndexItem)
+	keys := []string{}
+	for _, res := range index.Results {
+		if policy.Release != "" && res.OpenShiftRelease != policy.Release {
+			continue
+		}
+		if policy.Platform != "" && res.PlatformType != policy.Platform {
+			continue
+		}
+		key := fmt.Sprintf("%s_%s", res.OpenShiftRelease, res.PlatformType)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], res)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		results := index.GetRecentByPlatform(groups[key][0].OpenShiftRelease, groups[key][0].PlatformType, 0)
+		for pos, res := range results {
+			if res.IsPinned() || res.IsLatest {
+				continue
+			}
+			reason := ""
+			if policy.KeepLast > 0 && pos >= policy.KeepLast {
+				reason = fmt.Sprintf("not in the last %d results", policy.KeepLast)
+			}
+			if policy.OlderThan > 0 {
+				date, err := parseResultDate(res.Date)
+				if err != nil {
+					log.Warnf("unable to parse the date %q of result %s, skipping age policy: %v", res.Date, res.Name, err)
+				} else if now.Sub(date) > policy.OlderThan {
+					reason = fmt.Sprintf("older than %s", policy.OlderThan)
+				}
+			}
+			if reason == "" {
+				continue
+			}
+			items = append(items, &PruneItem{
+				Name:     res.Name,
+				Path:     res.Path,
+				Artifact: tagString(res.Tags, "objectArtifact"),
+				Release:  res.OpenShiftRelease,
+				Platform: res.PlatformType,
+				Date:     res.Date,
+				Reason:   reason,
+			})
+		}
+	}
+	return items
+}
+
+// PlanPrune returns the results removed by the retention policy.
+func (brs *BaselineConfig) PlanPrune(policy *PrunePolicy) ([]*PruneItem, error) {
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the index: %w", err)
+	}
+	return planPrune(index, policy, time.Now()), nil
+}
+
+// Prune removes the results (summary and archive) from the store, updating the index.
+// The index is always updated, including when some results fail to be removed, then
+// the errors are returned.
+func (brs *BaselineConfig) Prune(items []*PruneItem) error {
+	if err := brs.checkWriteAllowed(); err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, item := range items {
+		log.Infof("Removing result %s (%s)", item.Name, item.Reason)
+		if err := brs.store.Delete(item.Path); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove the result %s: %w", item.Name, err))
+			continue
+		}
+		if item.Artifact == "" {
+			continue
+		}
+		if err := brs.store.Delete(item.Artifact); err != nil {
+			log.Warnf("unable to remove the archive of result %s: %v", item.Name, err)
+		}
+	}
+	if _, err := brs.CreateBaselineIndex(nil); err != nil {
+		errs = append(errs, fmt.Errorf("unable to update the index: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// SetLifecycleTag sets the lifecycle tag (TagPinned, TagDeprecated) in the metadata of
+// the summary by name, updating the index. An empty value removes the tag.
+func (brs *BaselineConfig) SetLifecycleTag(name, tag, value string) error {
+	if err := brs.checkWriteAllowed(); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s/result/summary/%s.json", apiKeyPrefix, name)
+	// the metadata carries all the summary tags, reading them from the summary
+	// data when published without metadata.
+	tags, err := brs.readSummaryTags(key, nil)
+	if err != nil {
+		return fmt.Errorf("unable to read the result %s: %w", name, err)
+	}
+	meta := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		if sv, ok := v.(string); ok {
+			meta[k] = sv
+		}
+	}
+	delete(meta, tag)
+	if value != "" {
+		meta[tag] = value
+	}
+	if err := brs.store.SetMetadata(key, meta); err != nil {
+		return err
+	}
+	_, err = brs.CreateBaselineIndex(&IndexerOptions{Refresh: []string{key}})
+	return err
+}
@@ -0,0 +1,129 @@
+package baseline
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanPrune(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	newIndex := func() *baselineIndex {
+		index := &baselineIndex{
+			Results: []*baselineIndexItem{
+				{Name: "4.15_None_20240101000000", Date: "2024-01-01T00:00:00Z", OpenShiftRelease: "4.15", PlatformType: "None", Tags: map[string]interface{}{TagPinned: "true"}},
+				{Name: "4.15_None_20240201000000", Date: "2024-02-01T00:00:00Z", OpenShiftRelease: "4.15", PlatformType: "None", Tags: map[string]interface{}{"objectArtifact": "uploads/b.tar.gz"}},
+				{Name: "4.15_None_20240501000000", Date: "2024-05-01T00:00:00Z", OpenShiftRelease: "4.15", PlatformType: "None"},
+				{Name: "4.15_None_20240515000000", Date: "20240515000000", OpenShiftRelease: "4.15", PlatformType: "None"},
+				{Name: "4.15_AWS_20240101000000", Date: "2024-01-01T00:00:00Z", OpenShiftRelease: "4.15", PlatformType: "AWS"},
+			},
+		}
+		index.calculateLatest()
+		return index
+	}
+	names := func(items []*PruneItem) []string {
+		res := []string{}
+		for _, item := range items {
+			res = append(res, item.Name)
+		}
+		return res
+	}
+	tests := []struct {
+		name   string
+		policy *PrunePolicy
+		want   []string
+	}{
+		{
+			name:   "keep last",
+			policy: &PrunePolicy{KeepLast: 2},
+			want:   []string{"4.15_None_20240201000000"},
+		},
+		{
+			name:   "older than",
+			policy: &PrunePolicy{OlderThan: 60 * 24 * time.Hour},
+			want:   []string{"4.15_None_20240201000000"},
+		},
+		{
+			name:   "platform",
+			policy: &PrunePolicy{OlderThan: 60 * 24 * time.Hour, Platform: "AWS"},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(planPrune(newIndex(), tt.policy, now)))
+		})
+	}
+	// the latest is the pinned result, other results are pruned by age.
+	items := planPrune(newIndex(), &PrunePolicy{OlderThan: time.Hour}, now)
+	assert.Equal(t, []string{"4.15_None_20240515000000", "4.15_None_20240501000000", "4.15_None_20240201000000"}, names(items))
+	assert.Equal(t, "uploads/b.tar.gz", items[2].Artifact)
+}
+
+func TestCalculateLatestLifecycle(t *testing.T) {
+	index := &baselineIndex{
+		Results: []*baselineIndexItem{
+			{Name: "4.15_None_1", Date: "1", OpenShiftRelease: "4.15", PlatformType: "None"},
+			{Name: "4.15_None_2", Date: "2", OpenShiftRelease: "4.15", PlatformType: "None"},
+			{Name: "4.15_None_3", Date: "3", OpenShiftRelease: "4.15", PlatformType: "None", Tags: map[string]interface{}{TagDeprecated: "invalid"}},
+			{Name: "4.15_AWS_1", Date: "1", OpenShiftRelease: "4.15", PlatformType: "AWS", Tags: map[string]interface{}{TagDeprecated: "true"}},
+		},
+	}
+	index.calculateLatest()
+	assert.Equal(t, "4.15_None_2", index.Latest["4.15_None"].Name)
+	assert.NotContains(t, index.Latest, "4.15_AWS")
+
+	index.Results[0].Tags = map[string]interface{}{TagPinned: "true"}
+	index.calculateLatest()
+	assert.Equal(t, "4.15_None_1", index.Latest["4.15_None"].Name)
+	assert.True(t, index.Results[0].IsLatest)
+	assert.False(t, index.Results[1].IsLatest)
+}
+
+func TestLifecycleStore(t *testing.T) {
+	store, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+	brs := &BaselineConfig{store: store}
+
+	prefix := apiKeyPrefix + "/result/summary/"
+	for _, date := range []string{"20240101000000", "20240201000000"} {
+		summary := `{"setup":{"api":{"openshiftRelease":"4.15","platformType":"None","executionDate":"` + date + `","objectArtifact":"uploads/` + date + `.tar.gz"}}}`
+		assert.NoError(t, store.Put(prefix+"4.15_None_"+date+".json", strings.NewReader(summary), nil))
+		assert.NoError(t, store.Put("uploads/"+date+".tar.gz", strings.NewReader("archive"), nil))
+	}
+	_, err = brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+
+	// deprecate the latest
+	assert.NoError(t, brs.SetLifecycleTag("4.15_None_20240201000000", TagDeprecated, "invalid result"))
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.Equal(t, "4.15_None_20240101000000", index.Latest["4.15_None"].Name)
+	meta, err := store.GetMetadata(prefix + "4.15_None_20240201000000.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid result", meta[TagDeprecated])
+	assert.Equal(t, "4.15", meta["openshiftrelease"])
+
+	// undo the deprecation
+	assert.NoError(t, brs.SetLifecycleTag("4.15_None_20240201000000", TagDeprecated, ""))
+	index, err = brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.Equal(t, "4.15_None_20240201000000", index.Latest["4.15_None"].Name)
+
+	assert.Error(t, brs.SetLifecycleTag("4.15_None_20240301000000", TagPinned, "true"))
+
+	// prune all but the latest, the index is updated when a removal fails
+	items, err := brs.PlanPrune(&PrunePolicy{KeepLast: 1})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	missing := &PruneItem{Name: "4.15_None_20231201000000", Path: prefix + "4.15_None_20231201000000.json"}
+	err = brs.Prune(append([]*PruneItem{missing}, items...))
+	assert.ErrorContains(t, err, "unable to remove the result 4.15_None_20231201000000")
+	index, err = brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.Len(t, index.Results, 1)
+	_, err = store.Get("uploads/20240101000000.tar.gz")
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package baseline
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NewAPIHandler returns the HTTP handler serving the results API from the store,
+// using the same layout of the OPCT results API under /api/v0. Example:
+// /api/v0/result/summary/index.json. The object metadata is sent in the headers
+// x-amz-meta-*, as S3 does.
+func NewAPIHandler(store BaselineStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		key := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if !strings.HasPrefix(key, apiKeyPrefix+"/") || strings.HasSuffix(key, localMetadataSuffix) {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := store.Get(key)
+		if err != nil {
+			log.Debugf("Baseline API: %s %s: %v", r.Method, r.URL.Path, err)
+			http.NotFound(w, r)
+			return
+		}
+		if meta, err := store.GetMetadata(key); err == nil {
+			for k, v := range meta {
+				w.Header().Set("X-Amz-Meta-"+k, v)
+			}
+		}
+		log.Debugf("Baseline API: %s %s", r.Method, r.URL.Path)
+		http.ServeContent(w, r, path.Base(key), time.Time{}, bytes.NewReader(data))
+	})
+}
@@ -0,0 +1,43 @@
+package baseline
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIHandler(t *testing.T) {
+	store, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+	index := `{"date":"2024-01-01T00:00:00Z","results":[],"latest":{"4.15_None":{"name":"4.15_None_latest","sha256":"` + SummaryDigest([]byte(`{}`)) + `"}}}`
+	assert.NoError(t, store.Put(indexObjectKey, strings.NewReader(index), nil))
+	summary := "api/v0/result/summary/4.15_None_latest.json"
+	assert.NoError(t, store.Put(summary, strings.NewReader(`{}`), map[string]string{"platformType": "None"}))
+	assert.NoError(t, store.Put("uploads/archive.tar.gz", strings.NewReader("archive"), nil))
+
+	srv := httptest.NewServer(NewAPIHandler(store))
+	defer srv.Close()
+
+	// read with the client used by the report
+	brs, err := NewBaselineReportSummaryFromURL(srv.URL + "/api/v0")
+	assert.NoError(t, err)
+	_, err = brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.NoError(t, brs.GetLatestSummaryByPlatform("4.15", "None"))
+	assert.Equal(t, "4.15_None_latest", brs.GetBuffer().GetName())
+
+	meta, err := brs.GetStore().GetMetadata(summary)
+	assert.NoError(t, err)
+	assert.Equal(t, "None", meta["platformtype"])
+
+	// objects outside of the API are not served
+	for _, p := range []string{"/uploads/archive.tar.gz", "/api/v0/result/summary/4.15_None_latest.json.metadata.json", "/api/v0/not-found.json"} {
+		resp, err := http.Get(srv.URL + p)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, p)
+	}
+}
@@ -0,0 +1,123 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Schemes of the baseline store URL.
+const (
+	StoreSchemeS3        = "s3"
+	StoreSchemeS3HTTP    = "s3+http"
+	StoreSchemeS3HTTPS   = "s3+https"
+	StoreSchemeHTTP      = "http"
+	StoreSchemeHTTPS     = "https"
+	StoreSchemeLocalFile = "file"
+)
+
+// apiKeyPrefix is the prefix of the objects served by the results API.
+const apiKeyPrefix = "api/v0"
+
+// ErrStoreReadOnly is returned by stores not supporting write operations.
+var ErrStoreReadOnly = errors.New("operation not supported by read-only baseline store")
+
+// StoreObject is an object listed in the baseline store.
+type StoreObject struct {
+	// Key is the object key, relative to the store root. Example: api/v0/result/summary/index.json
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// BaselineStore is the storage backend of the baseline results. Object keys are
+// relative to the root of the store, following the layout:
+// - uploads/{archive}: result archives
+// - api/v0/result/summary/{name}.json: summaries served by the results API
+// - api/v0/result/summary/index.json: index of summaries
+type BaselineStore interface {
+	// String returns the URL of the store.
+	String() string
+
+	// Get reads the object.
+	Get(key string) ([]byte, error)
+
+	// GetMetadata reads the metadata of the object. Metadata keys are case-insensitive
+	// in S3, the keys are returned in lower case by all stores.
+	GetMetadata(key string) (map[string]string, error)
+
+	// Put writes the object with the metadata.
+	Put(key string, body io.ReadSeeker, meta map[string]string) error
+
+	// List lists the objects with the key prefix.
+	List(prefix string) ([]*StoreObject, error)
+
+	// Copy copies the object to a new key.
+	Copy(srcKey, dstKey string) error
+
+	// SetMetadata replaces the metadata of the object.
+	SetMetadata(key string, meta map[string]string) error
+
+	// Delete removes the object.
+	Delete(key string) error
+
+	// Invalidate expires the paths from the cache in front of the store (CDN).
+	// Stores without cache must return nil.
+	Invalidate(paths []string) error
+}
+
+// DefaultStoreURL returns the URL of the default store used to read the baseline
+// results (results API served by CloudFront).
+func DefaultStoreURL() string {
+	return reportBaseURL
+}
+
+// DefaultAdminStoreURL returns the URL of the default store used by administrative
+// tasks (publish, indexer), the S3 bucket backing the results API. The bucket can be
+// customized by the environment variables OPCT_EXP_BUCKET_NAME and OPCT_EXP_BUCKET_REGION.
+func DefaultAdminStoreURL() string {
+	bucketName := opctStorageBucketName
+	bucketRegion := opctStorageBucketRegion
+	if os.Getenv("OPCT_EXP_BUCKET_NAME") != "" {
+		bucketName = os.Getenv("OPCT_EXP_BUCKET_NAME")
+	}
+	if os.Getenv("OPCT_EXP_BUCKET_REGION") != "" {
+		bucketRegion = os.Getenv("OPCT_EXP_BUCKET_REGION")
+	}
+	return fmt.Sprintf("s3://%s?region=%s&cloudfront=%s", bucketName, bucketRegion, cloudfrontDistributionID)
+}
+
+// NewBaselineStore creates the store selected by the URL scheme:
+// - s3://bucket[/prefix][?region=us-east-1&cloudfront=<distribution id>]: AWS S3
+// - s3+http(s)://host:port/bucket[/prefix][?region=us-east-1]: S3-compatible storage (MinIO)
+// - http(s)://host[/path]: read-only results API
+// - file:///path, or a local path: local directory
+func NewBaselineStore(storeURL string) (BaselineStore, error) {
+	if storeURL == "" {
+		return nil, fmt.Errorf("empty baseline store URL")
+	}
+	if !strings.Contains(storeURL, "://") {
+		return newLocalStore(storeURL)
+	}
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseline store URL %q: %w", storeURL, err)
+	}
+	switch u.Scheme {
+	case StoreSchemeS3:
+		return newS3Store(u, "")
+	case StoreSchemeS3HTTP, StoreSchemeS3HTTPS:
+		endpoint := fmt.Sprintf("%s://%s", strings.TrimPrefix(u.Scheme, "s3+"), u.Host)
+		return newS3Store(u, endpoint)
+	case StoreSchemeHTTP, StoreSchemeHTTPS:
+		return newHTTPStore(u), nil
+	case StoreSchemeLocalFile:
+		return newLocalStore(u.Path)
+	}
+	return nil, fmt.Errorf("unsupported baseline store scheme %q, valid values: s3, s3+http, s3+https, http, https, file", u.Scheme)
+}
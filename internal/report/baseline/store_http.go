@@ -0,0 +1,124 @@
+package baseline
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	log "github.com/sirupsen/logrus"
+)
+
+// httpStore is the read-only baseline store backed by the results API served over
+// HTTP (CloudFront, or 'opct adm baseline serve'). The URL is the root of the API,
+// serving the objects with prefix api/v0. Example: https://example.com/api/v0/result/summary/index.json
+// is served by the store URL https://example.com/api/v0.
+type httpStore struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newHTTPStore(u *url.URL) *httpStore {
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = 5
+	retryLogger := log.New()
+	retryLogger.SetLevel(log.WarnLevel)
+	retryClient.Logger = retryLogger
+	return &httpStore{
+		baseURL: strings.TrimSuffix(u.String(), "/"),
+		client:  retryClient.StandardClient(),
+	}
+}
+
+func (st *httpStore) String() string {
+	return st.baseURL
+}
+
+// objectURL returns the URL of the object, only objects served by the API are supported.
+func (st *httpStore) objectURL(key string) (string, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix+"/") {
+		return "", fmt.Errorf("object %s is not served by the results API: %w", key, ErrStoreReadOnly)
+	}
+	return st.baseURL + strings.TrimPrefix(key, apiKeyPrefix), nil
+}
+
+// do sends the request to the object URL.
+func (st *httpStore) do(method, key string) (*http.Response, error) {
+	objURL, err := st.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, objURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("X-Custom-Header", "opct")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := st.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %v", err)
+	}
+	log.Debugf("Summary Report API (%s) response code: %s", objURL, resp.Status)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error BaselineAPI request: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func (st *httpStore) Get(key string) ([]byte, error) {
+	resp, err := st.do(http.MethodGet, key)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	rawResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+	return rawResp, nil
+}
+
+// GetMetadata returns the object metadata from the headers x-amz-meta-*, when served by S3.
+func (st *httpStore) GetMetadata(key string) (map[string]string, error) {
+	resp, err := st.do(http.MethodHead, key)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	meta := make(map[string]string)
+	for k := range resp.Header {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "x-amz-meta-") {
+			meta[strings.TrimPrefix(lk, "x-amz-meta-")] = resp.Header.Get(k)
+		}
+	}
+	return meta, nil
+}
+
+func (st *httpStore) Put(key string, body io.ReadSeeker, meta map[string]string) error {
+	return ErrStoreReadOnly
+}
+
+func (st *httpStore) List(prefix string) ([]*StoreObject, error) {
+	return nil, ErrStoreReadOnly
+}
+
+func (st *httpStore) Copy(srcKey, dstKey string) error {
+	return ErrStoreReadOnly
+}
+
+func (st *httpStore) SetMetadata(key string, meta map[string]string) error {
+	return ErrStoreReadOnly
+}
+
+func (st *httpStore) Delete(key string) error {
+	return ErrStoreReadOnly
+}
+
+func (st *httpStore) Invalidate(paths []string) error {
+	return nil
+}
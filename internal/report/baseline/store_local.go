@@ -0,0 +1,225 @@
+package baseline
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localMetadataSuffix is the suffix of the file storing the object metadata.
+const localMetadataSuffix = ".metadata.json"
+
+// localStore is the baseline store backed by a local directory, the object keys
+// are the file paths relative to the directory. Object metadata is saved in the
+// file {key}.metadata.json.
+type localStore struct {
+	dir string
+}
+
+func newLocalStore(dir string) (*localStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("missing directory in the baseline store URL")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseline store directory %q: %w", dir, err)
+	}
+	return &localStore{dir: abs}, nil
+}
+
+func (st *localStore) String() string {
+	return "file://" + st.dir
+}
+
+// Dir returns the directory of the store.
+func (st *localStore) Dir() string {
+	return st.dir
+}
+
+// path returns the file path of the object, preventing access outside of the directory.
+func (st *localStore) path(key string) (string, error) {
+	p := filepath.Join(st.dir, filepath.FromSlash(key))
+	if p != st.dir && !strings.HasPrefix(p, st.dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return p, nil
+}
+
+func (st *localStore) Get(key string) ([]byte, error) {
+	p, err := st.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (st *localStore) GetMetadata(key string) (map[string]string, error) {
+	p, err := st.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		return nil, fmt.Errorf("failed to get object metadata %s: %w", key, err)
+	}
+	meta := make(map[string]string)
+	data, err := os.ReadFile(p + localMetadataSuffix)
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object metadata %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse object metadata %s: %w", key, err)
+	}
+	return meta, nil
+}
+
+func (st *localStore) Put(key string, body io.ReadSeeker, meta map[string]string) error {
+	p, err := st.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for object %s: %w", key, err)
+	}
+	fd, err := os.Create(p)
+	if err != nil {
+		return fmt.Errorf("failed to create object %s: %w", key, err)
+	}
+	defer fd.Close()
+	if _, err := io.Copy(fd, body); err != nil {
+		return fmt.Errorf("failed to write object %s: %w", key, err)
+	}
+	return st.putMetadata(p, meta)
+}
+
+// putMetadata saves the metadata with lower case keys, as S3 does, removing the
+// existing metadata when empty.
+func (st *localStore) putMetadata(p string, meta map[string]string) error {
+	if len(meta) == 0 {
+		if err := os.Remove(p + localMetadataSuffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove object metadata: %w", err)
+		}
+		return nil
+	}
+	normalized := make(map[string]string, len(meta))
+	for k, v := range meta {
+		normalized[strings.ToLower(k)] = v
+	}
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode object metadata: %w", err)
+	}
+	return os.WriteFile(p+localMetadataSuffix, data, 0644)
+}
+
+func (st *localStore) List(prefix string) ([]*StoreObject, error) {
+	objects := []*StoreObject{}
+	err := filepath.WalkDir(st.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(p, localMetadataSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(st.dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		etag, err := fileMD5(p)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &StoreObject{
+			Key:          key,
+			Size:         info.Size(),
+			ETag:         etag,
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list objects in %s: %w", st.dir, err)
+	}
+	return objects, nil
+}
+
+func (st *localStore) Copy(srcKey, dstKey string) error {
+	src, err := st.path(srcKey)
+	if err != nil {
+		return err
+	}
+	fd, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to copy object %s: %w", srcKey, err)
+	}
+	defer fd.Close()
+	meta, err := st.GetMetadata(srcKey)
+	if err != nil {
+		return err
+	}
+	return st.Put(dstKey, fd, meta)
+}
+
+func (st *localStore) SetMetadata(key string, meta map[string]string) error {
+	p, err := st.path(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(p); err != nil {
+		return fmt.Errorf("failed to set object metadata %s: %w", key, err)
+	}
+	return st.putMetadata(p, meta)
+}
+
+func (st *localStore) Delete(key string) error {
+	p, err := st.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	if err := os.Remove(p + localMetadataSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete object metadata %s: %w", key, err)
+	}
+	return nil
+}
+
+func (st *localStore) Invalidate(paths []string) error {
+	return nil
+}
+
+// fileMD5 returns the MD5 hex digest of the file, the same format of the S3 ETag
+// for objects uploaded in a single part.
+func fileMD5(p string) (string, error) {
+	fd, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, fd); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
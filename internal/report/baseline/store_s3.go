@@ -0,0 +1,228 @@
+package baseline
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	log "github.com/sirupsen/logrus"
+)
+
+// s3Store is the baseline store backed by AWS S3 or S3-compatible storage (MinIO).
+type s3Store struct {
+	url      string
+	bucket   string
+	prefix   string
+	region   string
+	endpoint string
+
+	// cloudfrontDistributionID is the CloudFront distribution serving the bucket,
+	// used to invalidate the cache. Invalidation is skipped when empty.
+	cloudfrontDistributionID string
+
+	svc      *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// newS3Store creates the S3 store from the URL. The endpoint is set for S3-compatible
+// storage, using path-style addressing (s3+http://host:port/bucket/prefix).
+func newS3Store(u *url.URL, endpoint string) (*s3Store, error) {
+	st := &s3Store{
+		url:                      u.String(),
+		region:                   u.Query().Get("region"),
+		endpoint:                 endpoint,
+		cloudfrontDistributionID: u.Query().Get("cloudfront"),
+	}
+	if st.region == "" {
+		st.region = opctStorageBucketRegion
+	}
+	if endpoint == "" {
+		st.bucket = u.Host
+		st.prefix = strings.Trim(u.Path, "/")
+	} else {
+		parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)
+		st.bucket = parts[0]
+		if len(parts) > 1 {
+			st.prefix = parts[1]
+		}
+	}
+	if st.bucket == "" {
+		return nil, fmt.Errorf("missing bucket name in the baseline store URL %q", u.String())
+	}
+
+	svc, uploader, err := createS3Client(st.region, st.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	bucketExists, err := checkBucketExists(svc, st.bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !bucketExists {
+		return nil, fmt.Errorf("the OPCT storage does not exists")
+	}
+	st.svc = svc
+	st.uploader = uploader
+	return st, nil
+}
+
+func (st *s3Store) String() string {
+	return st.url
+}
+
+// isAWS returns true when the store is AWS S3 (not S3-compatible).
+func (st *s3Store) isAWS() bool {
+	return st.endpoint == ""
+}
+
+// objectKey returns the key of the object in the bucket.
+func (st *s3Store) objectKey(key string) string {
+	if st.prefix == "" {
+		return key
+	}
+	return path.Join(st.prefix, key)
+}
+
+func (st *s3Store) Get(key string) ([]byte, error) {
+	obj, err := st.svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(st.objectKey(key)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	defer obj.Body.Close()
+	return io.ReadAll(obj.Body)
+}
+
+func (st *s3Store) GetMetadata(key string) (map[string]string, error) {
+	obj, err := st.svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(st.objectKey(key)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object metadata %s: %w", key, err)
+	}
+	meta := make(map[string]string, len(obj.Metadata))
+	for k, v := range obj.Metadata {
+		meta[strings.ToLower(k)] = aws.StringValue(v)
+	}
+	return meta, nil
+}
+
+func (st *s3Store) Put(key string, body io.ReadSeeker, meta map[string]string) error {
+	_, err := st.uploader.Upload(&s3manager.UploadInput{
+		Bucket:   aws.String(st.bucket),
+		Key:      aws.String(st.objectKey(key)),
+		Metadata: aws.StringMap(meta),
+		Body:     body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object %s to bucket %s: %w", key, st.bucket, err)
+	}
+	return nil
+}
+
+func (st *s3Store) List(prefix string) ([]*StoreObject, error) {
+	objects := []*StoreObject{}
+	err := st.svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(st.bucket),
+		Prefix: aws.String(st.objectKey(prefix)),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			if st.prefix != "" {
+				key = strings.TrimPrefix(key, st.prefix+"/")
+			}
+			objects = append(objects, &StoreObject{
+				Key:          key,
+				Size:         aws.Int64Value(obj.Size),
+				ETag:         strings.Trim(aws.StringValue(obj.ETag), "\""),
+				LastModified: aws.TimeValue(obj.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in bucket %s: %w", st.bucket, err)
+	}
+	return objects, nil
+}
+
+func (st *s3Store) Copy(srcKey, dstKey string) error {
+	_, err := st.svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(st.bucket),
+		CopySource: aws.String(fmt.Sprintf("%v/%v", st.bucket, st.objectKey(srcKey))),
+		Key:        aws.String(st.objectKey(dstKey)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy object %s to %s: %w", srcKey, dstKey, err)
+	}
+	return nil
+}
+
+// SetMetadata replaces the metadata copying the object to itself, the content
+// and the ETag are preserved.
+func (st *s3Store) SetMetadata(key string, meta map[string]string) error {
+	_, err := st.svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:            aws.String(st.bucket),
+		CopySource:        aws.String(fmt.Sprintf("%v/%v", st.bucket, st.objectKey(key))),
+		Key:               aws.String(st.objectKey(key)),
+		Metadata:          aws.StringMap(meta),
+		MetadataDirective: aws.String(s3.MetadataDirectiveReplace),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set object metadata %s: %w", key, err)
+	}
+	return nil
+}
+
+func (st *s3Store) Delete(key string) error {
+	_, err := st.svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(st.objectKey(key)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Invalidate creates the CloudFront invalidation of the paths, when the distribution is set.
+func (st *s3Store) Invalidate(paths []string) error {
+	if st.cloudfrontDistributionID == "" {
+		log.Debugf("S3 store: CloudFront distribution is not set, skipping cache invalidation")
+		return nil
+	}
+	svcCloudfront, err := createCloudFrontClient(st.region)
+	if err != nil {
+		return fmt.Errorf("failed to create cloudfront client: %w", err)
+	}
+	var invalidationPaths []*string
+	for _, p := range paths {
+		invalidationPaths = append(invalidationPaths, aws.String(p))
+	}
+	_, err = svcCloudfront.CreateInvalidation(&cloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(st.cloudfrontDistributionID),
+		InvalidationBatch: &cloudfront.InvalidationBatch{
+			CallerReference: aws.String(time.Now().Format(time.RFC3339)),
+			Paths: &cloudfront.Paths{
+				Quantity: aws.Int64(int64(len(invalidationPaths))),
+				Items:    invalidationPaths,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf(`failed to create cache invalidation: %w
+Run the following command to invalidate the cache:
+aws cloudfront create-invalidation --distribution-id %s --paths %s`, err, st.cloudfrontDistributionID, strings.Join(paths, " "))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package baseline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBaselineStore(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "local path", url: dir, want: "file://" + dir},
+		{name: "file scheme", url: "file://" + dir, want: "file://" + dir},
+		{name: "results API", url: "https://example.com/api/v0/", want: "https://example.com/api/v0"},
+		{name: "empty", url: "", wantErr: true},
+		{name: "unsupported scheme", url: "ftp://example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := NewBaselineStore(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, st.String())
+		})
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	st, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+
+	key := "api/v0/result/summary/4.15_None_20240101.json"
+	assert.NoError(t, st.Put(key, strings.NewReader(`{"a":1}`), map[string]string{"openshiftRelease": "4.15"}))
+	assert.NoError(t, st.Put("uploads/archive.tar.gz", strings.NewReader("archive"), nil))
+
+	data, err := st.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(data))
+
+	meta, err := st.GetMetadata(key)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"openshiftrelease": "4.15"}, meta)
+
+	assert.NoError(t, st.Copy(key, "api/v0/result/summary/4.15_None_latest.json"))
+	meta, err = st.GetMetadata("api/v0/result/summary/4.15_None_latest.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "4.15", meta["openshiftrelease"])
+
+	objects, err := st.List("api/v0/result/summary/")
+	assert.NoError(t, err)
+	keys := []string{}
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+		assert.Equal(t, int64(7), obj.Size)
+		assert.NotEmpty(t, obj.ETag)
+	}
+	assert.ElementsMatch(t, []string{key, "api/v0/result/summary/4.15_None_latest.json"}, keys)
+
+	_, err = st.Get("../outside.json")
+	assert.Error(t, err)
+}
+
+func TestHTTPStoreReadOnly(t *testing.T) {
+	st, err := NewBaselineStore("https://example.com")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, st.Put("api/v0/result/summary/index.json", strings.NewReader(""), nil), ErrStoreReadOnly)
+	_, err = st.List("api/v0/")
+	assert.ErrorIs(t, err, ErrStoreReadOnly)
+	_, err = st.Get("uploads/archive.tar.gz")
+	assert.ErrorIs(t, err, ErrStoreReadOnly)
+}
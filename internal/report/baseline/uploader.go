@@ -1,23 +1,68 @@
 package baseline
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	log "github.com/sirupsen/logrus"
 )
 
-func (brs *BaselineConfig) UploadBaseline(filePath, resPath string, meta map[string]string, dryRun bool) error {
-	svcS3, uploader, err := brs.createS3Clients()
+// ArchiveHash returns the SHA-256 hex digest of the result archive.
+func ArchiveHash(filePath string) (string, error) {
+	fd, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to create S3 client and validate bucket: %w", err)
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer fd.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, fd); err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
 
-	// Upload the archive to the bucket
+// NewBaselineIdentity returns the stable identity of a baseline, built from the
+// sonobuoy run UUID and the archive hash: {uuid}:{sha256 prefix}.
+func NewBaselineIdentity(uuid, archiveHash string) string {
+	if uuid == "" {
+		uuid = "unknown"
+	}
+	if len(archiveHash) > 16 {
+		archiveHash = archiveHash[:16]
+	}
+	return fmt.Sprintf("%s:%s", uuid, archiveHash)
+}
+
+// FindBaselineByIdentity returns the summaries published with the identity, looking
+// up the index and the summary object (dataPath), which may not be indexed yet.
+func (brs *BaselineConfig) FindBaselineByIdentity(identity, dataPath string) []string {
+	found := map[string]struct{}{}
+	for _, res := range brs.readIndex().FindByIdentity(identity) {
+		found[res.Path] = struct{}{}
+	}
+	objectKeySummary := apiKeyPrefix + "/result/summary/" + dataPath
+	if meta, err := brs.store.GetMetadata(objectKeySummary); err == nil && meta["identity"] == identity {
+		found[objectKeySummary] = struct{}{}
+	}
+	paths := make([]string, 0, len(found))
+	for p := range found {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (brs *BaselineConfig) UploadBaseline(filePath, resPath string, meta map[string]string, dryRun bool) error {
+	if err := brs.checkWriteAllowed(); err != nil {
+		return fmt.Errorf("failed to validate the baseline store: %w", err)
+	}
+
+	// Upload the archive to the store
 	log.Debugf("UploadBaseline(): opening file %s", filePath)
 	fdArchive, err := os.Open(filePath)
 	if err != nil {
@@ -29,56 +74,42 @@ func (brs *BaselineConfig) UploadBaseline(filePath, resPath string, meta map[str
 	filenameArtifact := filepath.Base(filePath)
 	objectKeyArtifact := fmt.Sprintf("uploads/%s", filenameArtifact)
 	filenameSummary := resPath + "/opct-report-summary.json"
-	objectKeySummary := "api/v0/result/summary/" + meta["dataPath"]
+	objectKeySummary := apiKeyPrefix + "/result/summary/" + meta["dataPath"]
 
 	meta["objectArtifact"] = objectKeyArtifact
 	meta["objectSummary"] = objectKeySummary
 
 	// when metadata is set, parse it and add it to the object
-	// upload artifact to bucket
+	// upload artifact to the store
 	log.Debugf("UploadBaseline(): uploading to object %s", objectKeyArtifact)
-	s3ObjectURI := "s3://" + brs.bucketName + "/" + objectKeyArtifact
+	objectURI := brs.store.String() + "/" + objectKeyArtifact
 	if !dryRun {
-		_, err := uploader.Upload(&s3manager.UploadInput{
-			Bucket:   aws.String(brs.bucketName),
-			Key:      aws.String(objectKeyArtifact),
-			Metadata: aws.StringMap(meta),
-			Body:     fdArchive,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to upload file %s to bucket %s: %w", filenameArtifact, brs.bucketName, err)
+		if err := brs.store.Put(objectKeyArtifact, fdArchive, meta); err != nil {
+			return fmt.Errorf("failed to upload file %s to store %s: %w", filenameArtifact, brs.store, err)
 		}
-		log.Info("Results published successfully to ", s3ObjectURI)
-		// log.Debugf("UploadBaseline(): putObjectOutput: %v", putOutArchive)
+		log.Info("Results published successfully to ", objectURI)
 	} else {
-		log.Warnf("DRY-RUN mode: skipping upload to %s", s3ObjectURI)
+		log.Warnf("DRY-RUN mode: skipping upload to %s", objectURI)
 	}
 
-	// Saving summary to the bucket
+	// Saving summary to the store
 
 	log.Debugf("UploadBaseline(): opening file %q", filenameSummary)
 	fdSummary, err := os.Open(filenameSummary)
 	if err != nil {
 		return fmt.Errorf("failed to open file %s: %w", filenameSummary, err)
 	}
-	defer fdArchive.Close()
+	defer fdSummary.Close()
 
 	log.Debugf("UploadBaseline(): uploading baseline summary to %q", objectKeySummary)
-	s3ObjectURI = "s3://" + brs.bucketName + "/" + objectKeySummary
+	objectURI = brs.store.String() + "/" + objectKeySummary
 	if !dryRun {
-		_, err = svcS3.PutObject(&s3.PutObjectInput{
-			Bucket:   aws.String(brs.bucketName),
-			Key:      aws.String(objectKeySummary),
-			Body:     fdSummary,
-			Metadata: aws.StringMap(meta),
-		})
-		if err != nil {
-			return fmt.Errorf("failed to upload file %s to bucket %s: %w", filenameSummary, brs.bucketName, err)
+		if err := brs.store.Put(objectKeySummary, fdSummary, meta); err != nil {
+			return fmt.Errorf("failed to upload file %s to store %s: %w", filenameSummary, brs.store, err)
 		}
-		log.Info("Results published successfully to s3://", brs.bucketName, "/", objectKeySummary)
-
+		log.Info("Results published successfully to ", objectURI)
 	} else {
-		log.Warnf("DRY-RUN mode: skipping upload to %s", s3ObjectURI)
+		log.Warnf("DRY-RUN mode: skipping upload to %s", objectURI)
 	}
 
 	return nil
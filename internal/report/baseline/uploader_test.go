@@ -0,0 +1,54 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBaselineIdentity(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "archive.tar.gz")
+	assert.NoError(t, os.WriteFile(archive, []byte("archive"), 0644))
+	hash, err := ArchiveHash(archive)
+	assert.NoError(t, err)
+	// printf archive | sha256sum
+	assert.Equal(t, "0eb3e36bfb24dcd9bb1d1bece1531216b59539a8fde17ee80224af0653c92aa3", hash)
+
+	assert.Equal(t, "uuid-1:"+hash[:16], NewBaselineIdentity("uuid-1", hash))
+	assert.Equal(t, "unknown:abc", NewBaselineIdentity("", "abc"))
+
+	_, err = ArchiveHash(filepath.Join(t.TempDir(), "not-found"))
+	assert.Error(t, err)
+}
+
+func TestFindBaselineByIdentity(t *testing.T) {
+	store, err := NewBaselineStore(t.TempDir())
+	assert.NoError(t, err)
+	brs := &BaselineConfig{store: store}
+
+	prefix := apiKeyPrefix + "/result/summary/"
+	meta := func(identity, date string) map[string]string {
+		return map[string]string{
+			"openshiftRelease": "4.15",
+			"platformType":     "None",
+			"executionDate":    date,
+			"identity":         identity,
+		}
+	}
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240101000000.json", strings.NewReader(`{}`), meta("uuid-1:aaa", "2024-01-01")))
+	_, err = brs.CreateBaselineIndex(nil)
+	assert.NoError(t, err)
+	index, err := brs.ReadReportSummaryIndexFromAPI()
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid-1:aaa", index.Results[0].Identity)
+
+	// published, not indexed
+	assert.NoError(t, store.Put(prefix+"4.15_None_20240201000000.json", strings.NewReader(`{}`), meta("uuid-2:bbb", "2024-02-01")))
+
+	assert.Equal(t, []string{prefix + "4.15_None_20240101000000.json"}, brs.FindBaselineByIdentity("uuid-1:aaa", "4.15_None_20240101000000.json"))
+	assert.Equal(t, []string{prefix + "4.15_None_20240201000000.json"}, brs.FindBaselineByIdentity("uuid-2:bbb", "4.15_None_20240201000000.json"))
+	assert.Empty(t, brs.FindBaselineByIdentity("uuid-3:ccc", "4.15_None_20240301000000.json"))
+}
@@ -18,7 +18,9 @@ import (
 	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/metrics"
 	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
 	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/ci/sippy"
 	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
 	log "github.com/sirupsen/logrus"
 	"github.com/vmware-tanzu/sonobuoy/pkg/discovery"
 )
@@ -37,15 +39,27 @@ type ReportData struct {
 	Baseline *ReportResult  `json:"baseline,omitempty"`
 	Checks   *ReportChecks  `json:"checks,omitempty"`
 	Setup    *ReportSetup   `json:"setup,omitempty"`
+
+	// checkRules are the rules of the checks, the default rules when not set.
+	checkRules *CheckRules
+
+	// checkProfile is the check profile, detected from the cluster topology when not set.
+	checkProfile string
+
+	// checkWaivers are the waivers accepting failed checks.
+	checkWaivers []*CheckWaiver
 }
 
 type ReportChecks struct {
 	BaseURL    string       `json:"baseURL"`
 	EmptyValue string       `json:"emptyValue"`
+	Rules      string       `json:"rules,omitempty"`
+	Profile    string       `json:"profile,omitempty"`
 	Fail       []*SLOOutput `json:"failures"`
 	Pass       []*SLOOutput `json:"successes"`
 	Warn       []*SLOOutput `json:"warnings"`
 	Skip       []*SLOOutput `json:"skips"`
+	Waived     []*SLOOutput `json:"waived"`
 }
 
 type ReportResult struct {
@@ -79,6 +93,12 @@ type ReportSummary struct {
 	Runtime  *ReportSummaryRuntime `json:"runtime,omitempty"`
 	Headline string                `json:"headline"`
 	Features ReportSummaryFeatures `json:"features,omitempty"`
+
+	// FlakeDataSource is the source of flake data (Sippy) used by the filter pipeline.
+	FlakeDataSource *sippy.DataSourceStats `json:"flakeDataSource,omitempty"`
+
+	// BaselineAPI holds the baseline results aggregated by the BaselineAPI filter.
+	BaselineAPI *summary.BaselineAPIOptions `json:"baselineAPI,omitempty"`
 }
 
 type ReportSummaryFeatures struct {
@@ -161,37 +181,16 @@ type ReportPlugin struct {
 
 	Tests map[string]*plugin.TestItem `json:"tests,omitempty"`
 
-	// Filters
-	// SuiteOnly
-	FailedFilter1 []*ReportTestFailure `json:"failedTestsFilter1"`
-	TagsFilter1   string               `json:"tagsFailuresFilter1"`
-
-	// Filter: BaselineArchive
-	FailedFilter2 []*ReportTestFailure `json:"failedTestsFilter2"`
-	TagsFilter2   string               `json:"tagsFailuresFilter2"`
-
-	// Filter: FlakeAPI
-	FailedFilter3 []*ReportTestFailure `json:"failedTestsFilter3"`
-	TagsFilter3   string               `json:"tagsFailuresFilter3"`
-
-	// Filter: BaselineAPI
-	FailedFilter4 []*ReportTestFailure `json:"failedTestsFilter4"`
-	TagsFilter4   string               `json:"tagsFailuresFilter4"`
-
-	// Filter: KnownFailures
-	FailedFilter5 []*ReportTestFailure `json:"failedTestsFilter5"`
-	TagsFilter5   string               `json:"tagsFailuresFilter5"`
-
-	// Filter: Replay
-	FailedFilter6 []*ReportTestFailure `json:"failedTestsFilter6"`
-	TagsFilter6   string               `json:"tagsFailuresFilter6"`
+	// Filters is the ordered list of stages of the filter pipeline.
+	Filters []*ReportPluginFilter `json:"filters"`
 
 	// Final results after filters
 	FailedFiltered []*ReportTestFailure `json:"failedFiltered"`
 	TagsFiltered   string               `json:"tagsFailuresFiltered"`
 }
 
-func (rp *ReportPlugin) BuildFailedData(filterID string, dataFailures []string) {
+// BuildFailedData builds the list of failures with details, and the tags counter.
+func (rp *ReportPlugin) BuildFailedData(dataFailures []string) ([]*ReportTestFailure, string) {
 	failures := []*ReportTestFailure{}
 	tags := plugin.NewTestTagsEmpty(len(dataFailures))
 	for _, f := range dataFailures {
@@ -204,6 +203,8 @@ func (rp *ReportPlugin) BuildFailedData(filterID string, dataFailures []string)
 			ID:            rp.Tests[f].ID,
 			Name:          rp.Tests[f].Name,
 			Documentation: rp.Tests[f].Documentation,
+			Baseline:      rp.Tests[f].Baseline,
+			Decisions:     rp.Tests[f].Decisions,
 		}
 		if rp.Tests[f].Flake != nil {
 			rtf.FlakeCount = rp.Tests[f].Flake.CurrentFlakes
@@ -215,27 +216,33 @@ func (rp *ReportPlugin) BuildFailedData(filterID string, dataFailures []string)
 		tags.Add(&f)
 		failures = append(failures, rtf)
 	}
-	failures = sortReportTestFailure(failures)
-	switch filterID {
-	case "final":
-		rp.FailedFiltered = failures
-		rp.TagsFiltered = tags.ShowSorted()
-	case "F1":
-		rp.FailedFilter1 = failures
-		rp.TagsFilter1 = tags.ShowSorted()
-	case "F3":
-		rp.FailedFilter3 = failures
-		rp.TagsFilter3 = tags.ShowSorted()
-	case "F4":
-		rp.FailedFilter4 = failures
-		rp.TagsFilter4 = tags.ShowSorted()
-	case "F5":
-		rp.FailedFilter5 = failures
-		rp.TagsFilter5 = tags.ShowSorted()
-	case "F6":
-		rp.FailedFilter6 = failures
-		rp.TagsFilter6 = tags.ShowSorted()
+	return sortReportTestFailure(failures), tags.ShowSorted()
+}
+
+// GetFilter returns the filter stage by ID, or nil when not found.
+func (rp *ReportPlugin) GetFilter(id string) *ReportPluginFilter {
+	for _, f := range rp.Filters {
+		if f.ID == id {
+			return f
+		}
 	}
+	return nil
+}
+
+// ReportPluginFilter is the result of a stage in the filter pipeline.
+type ReportPluginFilter struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Skipped     bool   `json:"skipped"`
+
+	// Previous is the counter of failures received from the previous stage.
+	Previous int64 `json:"previous"`
+	Failures int64 `json:"failures"`
+	Excluded int64 `json:"excluded"`
+
+	// ExcludedTests is the list of failures removed by the filter.
+	ExcludedTests []*ReportTestFailure `json:"excludedTests"`
+	Tags          string               `json:"tagsExcluded"`
 }
 
 type ReportPluginStat struct {
@@ -248,31 +255,6 @@ type ReportPluginStat struct {
 	Timeout   int64  `json:"timeout"`
 	Skipped   int64  `json:"skipped"`
 
-	// Filters
-	// Filter: SuiteOnly
-	FilterSuite     int64 `json:"filter1Suite"`
-	Filter1Excluded int64 `json:"filter1Excluded"`
-
-	// Filter: BaselineArchive (deprecated soon)
-	FilterBaseline  int64 `json:"filter2Baseline"`
-	Filter2Excluded int64 `json:"filter2Excluded"`
-
-	// Filter: FlakeCI
-	FilterFailedPrio int64 `json:"filter3FailedPriority"`
-	Filter3Excluded  int64 `json:"filter3Excluded"`
-
-	// Filter: BaselineAPI
-	FilterFailedAPI int64 `json:"filter4FailedAPI"`
-	Filter4Excluded int64 `json:"filter4Excluded"`
-
-	// Filter: KnownFailures
-	Filter5Failures int64 `json:"filter5Failures"`
-	Filter5Excluded int64 `json:"filter5Excluded"`
-
-	// Filter: Replay
-	Filter6Failures int64 `json:"filter6Failures"`
-	Filter6Excluded int64 `json:"filter6Excluded"`
-
 	FilterFailures int64 `json:"filterFailures"`
 }
 
@@ -283,11 +265,24 @@ type ReportTestFailure struct {
 	FlakePerc     float64 `json:"flakePerc"`
 	FlakeCount    int64   `json:"flakeCount"`
 	ErrorsCount   int64   `json:"errorsTotal"`
+
+	// Baseline is the failure frequency of the test in the baseline results.
+	Baseline *plugin.BaselineFrequency `json:"baseline,omitempty"`
+
+	// Decisions is the trace of the filter pipeline decisions to the failure.
+	Decisions []*plugin.FilterDecision `json:"decisions,omitempty"`
+
+	// History is the failure history of the test across the baselines, set
+	// by 'opct report --baseline-history'.
+	History *baseline.TestHistory `json:"history,omitempty"`
 }
 
 type ReportSetup struct {
 	Frontend *ReportSetupFrontend `json:"frontend,omitempty"`
 	API      *ReportSetupAPI      `json:"api,omitempty"`
+
+	// Acceptance is the evaluation of the acceptance policy, set when publishing a baseline.
+	Acceptance *AcceptanceResult `json:"acceptance,omitempty"`
 }
 type ReportSetupFrontend struct {
 	EmbedData bool
@@ -304,6 +299,18 @@ type ReportSetupAPI struct {
 	ProviderName     string `json:"providerName,omitempty"`
 	InfraTopology    string `json:"infraTopology,omitempty"`
 	Workflow         string `json:"workflow,omitempty"`
+
+	// ArchiveHash is the SHA-256 digest of the result archive, set when publishing a baseline.
+	ArchiveHash string `json:"archiveHash,omitempty"`
+
+	// Identity is the stable identity of the baseline, built from the run UUID and
+	// the archive hash, set when publishing a baseline.
+	Identity string `json:"identity,omitempty"`
+
+	// Acceptance is the status of the acceptance policy, and AcceptanceWarnings the
+	// comma-separated IDs of the warnings, set when publishing a baseline.
+	Acceptance         string `json:"acceptance,omitempty"`
+	AcceptanceWarnings string `json:"acceptanceWarnings,omitempty"`
 }
 
 type ReportRuntime struct {
@@ -324,6 +331,20 @@ func NewReportData(embedFrontend bool) *ReportData {
 	}
 }
 
+// LoadReportDataFromFile reads the report data saved by SaveResults (opct-report.json),
+// or the summarized report data (opct-report-summary.json).
+func LoadReportDataFromFile(path string) (*ReportData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read report data: %w", err)
+	}
+	re := &ReportData{}
+	if err := json.Unmarshal(data, re); err != nil {
+		return nil, fmt.Errorf("unable to parse report data %s: %w", path, err)
+	}
+	return re, nil
+}
+
 // Populate is a entrypoint to initialize, trigger the data source processors,
 // and finalize the report data structure used by frontend (HTML or CLI).
 func (re *ReportData) Populate(cs *summary.ConsolidatedSummary) error {
@@ -359,30 +380,65 @@ func (re *ReportData) Populate(cs *summary.ConsolidatedSummary) error {
 		HasMetricsData:   cs.Provider.HasMetrics,
 		HasInstallConfig: cs.Provider.HasInstallConfig,
 	}
+	re.Summary.FlakeDataSource = cs.GetFlakeDataSource()
+	re.Summary.BaselineAPI = cs.BaselineAPIOptions
 
 	// Checks need to run after the report is populated, so it can evaluate the
 	// data entirely.
-	checks := NewCheckSummary(re)
-	err := checks.Run()
+	if err := re.RunChecks(); err != nil {
+		return err
+	}
+
+	cs.Timers.Add("report-populate")
+	re.Summary.Runtime.Timers = cs.Timers
+	return nil
+}
+
+// RunChecks evaluates the checks over the report data with the rules, profile and
+// waivers set, replacing the previous results. The profile is detected from the
+// cluster topology when not set.
+func (re *ReportData) RunChecks() error {
+	if re.checkRules == nil {
+		re.checkRules = NewDefaultCheckRules()
+	}
+	profile := re.checkProfile
+	if profile == "" {
+		profile = DetectCheckProfile(re.Provider)
+		log.Debugf("Check profile detected from the cluster topology: %s", profile)
+	}
+	rules, err := re.checkRules.WithProfile(profile)
+	if err != nil {
+		return fmt.Errorf("unable to apply the check profile: %w", err)
+	}
+	checks := NewCheckSummaryWithRules(re, rules)
+	checks.SetWaivers(re, re.checkWaivers)
+	err = checks.Run()
 	if err != nil {
 		log.Debugf("one or more errors found when running checks: %v", err)
 	}
-	pass, fail, warn, skip := checks.GetCheckResults()
+	pass, fail, warn, skip, waived := checks.GetCheckResults()
 	re.Checks = &ReportChecks{
 		BaseURL:    checks.GetBaseURL(),
 		EmptyValue: CheckIdEmptyValue,
+		Rules:      checks.GetRules(),
+		Profile:    checks.GetProfile(),
 		Pass:       pass,
 		Fail:       fail,
 		Warn:       warn,
 		Skip:       skip,
+		Waived:     waived,
+	}
+	if re.Summary == nil {
+		return nil
+	}
+	if re.Summary.Alerts == nil {
+		re.Summary.Alerts = &ReportSummaryAlerts{}
 	}
+	re.Summary.Alerts.Checks, re.Summary.Alerts.ChecksMessage = "", ""
 	if len(re.Checks.Fail) > 0 {
 		re.Summary.Alerts.Checks = "danger"
 		re.Summary.Alerts.ChecksMessage = fmt.Sprintf("%d", len(re.Checks.Fail))
 	}
-
-	cs.Timers.Add("report-populate")
-	re.Summary.Runtime.Timers = cs.Timers
 	return nil
 }
 
@@ -603,30 +659,22 @@ func (re *ReportData) populatePluginConformance(rs *summary.ResultSummary, reRes
 		return nil
 	}
 
-	// Set counters for each filters in the pipeline
-	// Filter SuiteOnly
-	reResult.Plugins[pluginID].Stat.FilterSuite = int64(len(pluginSum.FailedFilter1))
-	reResult.Plugins[pluginID].Stat.Filter1Excluded = int64(len(pluginSum.FailedExcludedFilter1))
-
-	// Filter Baseline
-	reResult.Plugins[pluginID].Stat.FilterBaseline = int64(len(pluginSum.FailedFilter2))
-	reResult.Plugins[pluginID].Stat.Filter2Excluded = int64(len(pluginSum.FailedExcludedFilter2))
-
-	// Filter FlakeAPI
-	reResult.Plugins[pluginID].Stat.FilterFailedPrio = int64(len(pluginSum.FailedFilter3))
-	reResult.Plugins[pluginID].Stat.Filter3Excluded = int64(len(pluginSum.FailedExcludedFilter3))
-
-	// Filter BaselineAPI
-	reResult.Plugins[pluginID].Stat.FilterFailedAPI = int64(len(pluginSum.FailedFilter4))
-	reResult.Plugins[pluginID].Stat.Filter4Excluded = int64(len(pluginSum.FailedExcludedFilter4))
-
-	// Filter KnownFailures
-	reResult.Plugins[pluginID].Stat.Filter5Failures = int64(len(pluginSum.FailedFilter5))
-	reResult.Plugins[pluginID].Stat.Filter5Excluded = int64(len(pluginSum.FailedExcludedFilter5))
-
-	// Filter Replay
-	reResult.Plugins[pluginID].Stat.Filter6Failures = int64(len(pluginSum.FailedFilter6))
-	reResult.Plugins[pluginID].Stat.Filter6Excluded = int64(len(pluginSum.FailedExcludedFilter6))
+	// Set counters and excluded failures for each filter in the pipeline
+	previous := int64(len(pluginSum.FailedList))
+	for _, stage := range pluginSum.Filters {
+		excluded, tags := reResult.Plugins[pluginID].BuildFailedData(stage.Excluded)
+		reResult.Plugins[pluginID].Filters = append(reResult.Plugins[pluginID].Filters, &ReportPluginFilter{
+			ID:            stage.ID,
+			Description:   stage.Description,
+			Skipped:       stage.Skipped,
+			Previous:      previous,
+			Failures:      int64(len(stage.Failures)),
+			Excluded:      int64(len(stage.Excluded)),
+			ExcludedTests: excluded,
+			Tags:          tags,
+		})
+		previous = int64(len(stage.Failures))
+	}
 
 	// Filter Failures (result)
 	reResult.Plugins[pluginID].Stat.FilterFailures = int64(len(pluginSum.FailedFiltered))
@@ -641,7 +689,8 @@ func (re *ReportData) populatePluginConformance(rs *summary.ResultSummary, reRes
 		// Replay is a special case, it can have failures in the filter6 as it is
 		// a replay of the failures from original suite which can have perm failures or bugs.
 		// Replay helps in debugging and getting more confidence in the results.
-		if pluginID == plugin.PluginNameConformanceReplay && reResult.Plugins[pluginID].Stat.Filter6Failures != 0 {
+		if f := reResult.Plugins[pluginID].GetFilter(plugin.FilterNameReplay); pluginID == plugin.PluginNameConformanceReplay &&
+			f != nil && !f.Skipped && f.Failures != 0 {
 			reResult.Plugins[pluginID].Stat.Result = "---"
 		}
 	}
@@ -649,9 +698,9 @@ func (re *ReportData) populatePluginConformance(rs *summary.ResultSummary, reRes
 	if reResult.Plugins[pluginID].Stat.FilterFailures != 0 {
 		pluginAlert = "danger"
 		pluginAlertMessage = fmt.Sprintf("%d", int64(len(pluginSum.FailedFiltered)))
-	} else if reResult.Plugins[pluginID].Stat.FilterSuite != 0 {
+	} else if f := reResult.Plugins[pluginID].GetFilter(plugin.FilterNameSuiteOnly); f != nil && f.Failures != 0 {
 		pluginAlert = "warning"
-		pluginAlertMessage = fmt.Sprintf("%d", int64(len(pluginSum.FailedFilter1)))
+		pluginAlertMessage = fmt.Sprintf("%d", f.Failures)
 	}
 
 	if _, ok := rs.GetSonobuoy().PluginsDefinition[pluginID]; ok {
@@ -663,24 +712,8 @@ func (re *ReportData) populatePluginConformance(rs *summary.ResultSummary, reRes
 		}
 	}
 
-	// Filter failures
 	// Final filters (results/priority)
-	reResult.Plugins[pluginID].BuildFailedData("final", pluginSum.FailedFiltered)
-
-	// Filter flakeAPI
-	reResult.Plugins[pluginID].BuildFailedData("F3", pluginSum.FailedExcludedFilter3)
-
-	// Filter BaselineAPI
-	reResult.Plugins[pluginID].BuildFailedData("F4", pluginSum.FailedExcludedFilter4)
-
-	// Filter Replay
-	reResult.Plugins[pluginID].BuildFailedData("F6", pluginSum.FailedExcludedFilter6)
-
-	// Filter KnownFailures
-	reResult.Plugins[pluginID].BuildFailedData("F5", pluginSum.FailedExcludedFilter5)
-
-	// Filter SuiteOnly
-	reResult.Plugins[pluginID].BuildFailedData("F1", pluginSum.FailedExcludedFilter1)
+	reResult.Plugins[pluginID].FailedFiltered, reResult.Plugins[pluginID].TagsFiltered = reResult.Plugins[pluginID].BuildFailedData(pluginSum.FailedFiltered)
 
 	// update alerts
 	if rs.Name == summary.ResultSourceNameProvider && pluginAlert != "" {
@@ -0,0 +1,40 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+)
+
+func TestReportDataRunChecks(t *testing.T) {
+	re := newRulesTestReport()
+	re.Provider.Version.OpenShift.ClusterID = "1234-abcd"
+	re.Provider.Nodes = []*summary.Node{{ControlPlane: true}, {ControlPlane: true}, {ControlPlane: true}}
+	re.Summary = &ReportSummary{Alerts: &ReportSummaryAlerts{Checks: "danger", ChecksMessage: "99"}}
+
+	data, err := json.Marshal(re)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), ReportFileNameIndexJSON)
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+
+	// checks are evaluated again from the saved report data, with the profile and waivers set.
+	saved, err := LoadReportDataFromFile(path)
+	assert.NoError(t, err)
+	saved.SetCheckProfile(CheckProfileHA)
+	waiver := &CheckWaiver{ID: "OPCT-030", Justification: "single zone", Approver: "jdoe", Expires: "2999-12-31"}
+	assert.NoError(t, waiver.validate())
+	saved.SetCheckWaivers([]*CheckWaiver{waiver})
+	assert.NoError(t, saved.RunChecks())
+	assert.Equal(t, CheckProfileHA, saved.Checks.Profile)
+
+	assert.Len(t, saved.Checks.Waived, 1)
+	assert.Equal(t, "OPCT-030", saved.Checks.Waived[0].ID)
+	assert.NotEmpty(t, saved.Checks.Fail)
+	assert.Equal(t, fmt.Sprintf("%d", len(saved.Checks.Fail)), saved.Summary.Alerts.ChecksMessage)
+}
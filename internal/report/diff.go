@@ -0,0 +1,498 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	table "github.com/jedib0t/go-pretty/v6/table"
+
+	vfs "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/assets"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/archive"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+)
+
+// Output formats of the report diff.
+const (
+	DiffFormatTable    = "table"
+	DiffFormatJSON     = "json"
+	DiffFormatMarkdown = "markdown"
+	DiffFormatHTML     = "html"
+)
+
+// DiffFormats are the supported output formats of the report diff.
+var DiffFormats = []string{DiffFormatTable, DiffFormatJSON, DiffFormatMarkdown, DiffFormatHTML}
+
+// Sources of the error counters compared in the report diff.
+const (
+	DiffErrorSourceTests = "tests"
+	DiffErrorSourceEtcd  = "etcd"
+
+	// DiffTemplateHTML is the template of the HTML output, in ReportTemplateBasePath.
+	DiffTemplateHTML = "diff.html"
+)
+
+// CountDiff is the change of a counter from the report A to B.
+type CountDiff struct {
+	A     int64 `json:"a"`
+	B     int64 `json:"b"`
+	Delta int64 `json:"delta"`
+}
+
+func newCountDiff(a, b int64) *CountDiff {
+	return &CountDiff{A: a, B: b, Delta: b - a}
+}
+
+// String returns the counter change. Example: 10 -> 12 (+2)
+func (cd *CountDiff) String() string {
+	if cd.Delta == 0 {
+		return fmt.Sprintf("%d", cd.B)
+	}
+	return fmt.Sprintf("%d -> %d (%+d)", cd.A, cd.B, cd.Delta)
+}
+
+// PluginDiff is the comparison of the plugin results.
+type PluginDiff struct {
+	Name           string     `json:"name"`
+	Total          *CountDiff `json:"total"`
+	Passed         *CountDiff `json:"passed"`
+	Failed         *CountDiff `json:"failed"`
+	FailedFiltered *CountDiff `json:"failedFiltered"`
+
+	// NewFailures are the filtered failures in B not failing in A.
+	NewFailures []string `json:"newFailures"`
+
+	// ResolvedFailures are the filtered failures in A not failing in B.
+	ResolvedFailures []string `json:"resolvedFailures"`
+
+	// StillFailing are the filtered failures in both A and B.
+	StillFailing []string `json:"stillFailing"`
+}
+
+// CheckDiff is a check which result changed from the report A to B.
+type CheckDiff struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+// FieldDiff is a cluster field (version or infrastructure) changed from the report A to B.
+type FieldDiff struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+// ErrorCounterDiff is the change of an error counter from the report A to B.
+type ErrorCounterDiff struct {
+	Source string `json:"source"`
+	Name   string `json:"name"`
+	*CountDiff
+}
+
+// LatencyDiff is the change of a latency (ms) from the report A to B.
+type LatencyDiff struct {
+	A     float64 `json:"a"`
+	B     float64 `json:"b"`
+	Delta float64 `json:"delta"`
+}
+
+// String returns the latency change. Example: 10.000 -> 12.500 (+2.500) ms
+func (ld *LatencyDiff) String() string {
+	if ld.Delta == 0 {
+		return fmt.Sprintf("%.3f ms", ld.B)
+	}
+	return fmt.Sprintf("%.3f -> %.3f (%+.3f) ms", ld.A, ld.B, ld.Delta)
+}
+
+// EtcdDiff is the change of the etcd slow requests parsed from must-gather logs.
+type EtcdDiff struct {
+	SlowRequests *CountDiff   `json:"slowRequests"`
+	Mean         *LatencyDiff `json:"mean,omitempty"`
+	P99          *LatencyDiff `json:"p99,omitempty"`
+}
+
+// ReportDiff is the comparison of two reports, from A (before) to B (after).
+type ReportDiff struct {
+	NameA   string        `json:"nameA"`
+	NameB   string        `json:"nameB"`
+	Cluster []*FieldDiff  `json:"cluster"`
+	Plugins []*PluginDiff `json:"plugins"`
+	Checks  []*CheckDiff  `json:"checks"`
+
+	// ErrorCounters are the error counters changed, from the failed tests and etcd logs.
+	ErrorCounters []*ErrorCounterDiff `json:"errorCounters"`
+
+	// Etcd is the change of the etcd latency, nil when not available in both reports.
+	Etcd *EtcdDiff `json:"etcd,omitempty"`
+}
+
+// GetFailedFilteredNames returns the names of the failures after the filter pipeline.
+func (rp *ReportPlugin) GetFailedFilteredNames() []string {
+	names := []string{}
+	for _, f := range rp.FailedFiltered {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// getPlugin returns the plugin from the report, or an empty plugin when not found.
+func getPlugin(re *ReportData, name string) *ReportPlugin {
+	if re == nil || re.Provider == nil || re.Provider.Plugins[name] == nil {
+		return &ReportPlugin{Stat: &ReportPluginStat{}}
+	}
+	p := re.Provider.Plugins[name]
+	if p.Stat == nil {
+		p.Stat = &ReportPluginStat{}
+	}
+	return p
+}
+
+// getCheckResults returns the check result by ID, or by name when the check has no ID.
+func getCheckResults(re *ReportData) map[string]*SLOOutput {
+	results := make(map[string]*SLOOutput)
+	if re == nil || re.Checks == nil {
+		return results
+	}
+	for _, outputs := range [][]*SLOOutput{re.Checks.Pass, re.Checks.Fail, re.Checks.Warn, re.Checks.Skip, re.Checks.Waived} {
+		for _, check := range outputs {
+			key := check.ID
+			if key == "" || key == CheckIdEmptyValue {
+				key = check.SLO
+			}
+			results[key] = check
+		}
+	}
+	return results
+}
+
+// getClusterFields returns the version and infrastructure fields of the cluster compared in the diff.
+func getClusterFields(re *ReportData) map[string]string {
+	fields := make(map[string]string)
+	if re == nil || re.Provider == nil {
+		return fields
+	}
+	if v := re.Provider.Version; v != nil {
+		if v.OpenShift != nil {
+			fields["OpenShift version"] = v.OpenShift.Desired
+			fields["OpenShift channel"] = v.OpenShift.Channel
+			fields["Cluster ID"] = v.OpenShift.ClusterID
+		}
+		fields["Kubernetes version"] = v.Kubernetes
+		fields["OPCT server version"] = v.OPCTServer
+		fields["OPCT client version"] = v.OPCTClient
+	}
+	if i := re.Provider.Infra; i != nil {
+		fields["Platform type"] = i.PlatformType
+		fields["Platform name"] = i.PlatformName
+		fields["Topology"] = i.Topology
+		fields["Control plane topology"] = i.ControlPlaneTopology
+		fields["Network type"] = i.NetworkType
+	}
+	fields["Nodes"] = fmt.Sprintf("%d", len(re.Provider.Nodes))
+	return fields
+}
+
+// getErrorCounters returns the error counters by source: failed tests and etcd logs.
+func getErrorCounters(re *ReportData) map[string]archive.ErrorCounter {
+	counters := make(map[string]archive.ErrorCounter)
+	if re == nil || re.Provider == nil {
+		return counters
+	}
+	if re.Provider.ErrorCounters != nil {
+		counters[DiffErrorSourceTests] = *re.Provider.ErrorCounters
+	}
+	if mg := re.Provider.MustGatherInfo; mg != nil && mg.ErrorEtcdLogs != nil {
+		counters[DiffErrorSourceEtcd] = mg.ErrorEtcdLogs.ErrorCounters
+	}
+	return counters
+}
+
+// parseLatency parses the latency statistic of etcd logs. Example: "12.345 (ms)"
+func parseLatency(stat string) (float64, bool) {
+	fields := strings.Fields(stat)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	return v, err == nil
+}
+
+// getEtcdSlowRequests returns the statistics of etcd slow requests parsed from must-gather logs,
+// or nil when not available.
+func getEtcdSlowRequests(re *ReportData) *mustgather.BucketFilterStat {
+	if re == nil || re.Provider == nil || re.Provider.MustGatherInfo == nil || re.Provider.MustGatherInfo.ErrorEtcdLogs == nil {
+		return nil
+	}
+	return re.Provider.MustGatherInfo.ErrorEtcdLogs.FilterRequestSlowAll[mustgather.BucketRangeNameAll]
+}
+
+// newEtcdDiff compares the etcd slow requests, returning nil when not available in both reports.
+func newEtcdDiff(a, b *ReportData) *EtcdDiff {
+	sa, sb := getEtcdSlowRequests(a), getEtcdSlowRequests(b)
+	if sa == nil && sb == nil {
+		return nil
+	}
+	if sa == nil {
+		sa = &mustgather.BucketFilterStat{}
+	}
+	if sb == nil {
+		sb = &mustgather.BucketFilterStat{}
+	}
+	latency := func(va, vb string) *LatencyDiff {
+		la, okA := parseLatency(va)
+		lb, okB := parseLatency(vb)
+		if !okA && !okB {
+			return nil
+		}
+		return &LatencyDiff{A: la, B: lb, Delta: lb - la}
+	}
+	return &EtcdDiff{
+		SlowRequests: newCountDiff(sa.RequestCount, sb.RequestCount),
+		Mean:         latency(sa.StatMean, sb.StatMean),
+		P99:          latency(sa.StatPerc99, sb.StatPerc99),
+	}
+}
+
+// NewReportDiff compares the report A (before) with the report B (after).
+func NewReportDiff(nameA string, a *ReportData, nameB string, b *ReportData) *ReportDiff {
+	diff := &ReportDiff{
+		NameA:         nameA,
+		NameB:         nameB,
+		Cluster:       []*FieldDiff{},
+		Plugins:       []*PluginDiff{},
+		Checks:        []*CheckDiff{},
+		ErrorCounters: []*ErrorCounterDiff{},
+	}
+
+	fieldsA, fieldsB := getClusterFields(a), getClusterFields(b)
+	fields := []string{}
+	for name := range fieldsA {
+		fields = append(fields, name)
+	}
+	for name := range fieldsB {
+		if _, ok := fieldsA[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	for _, name := range fields {
+		if fieldsA[name] != fieldsB[name] {
+			diff.Cluster = append(diff.Cluster, &FieldDiff{Name: name, A: fieldsA[name], B: fieldsB[name]})
+		}
+	}
+	for _, name := range plugin.ConformancePlugins {
+		pa, pb := getPlugin(a, name), getPlugin(b, name)
+		if pa.Stat.Total == 0 && pb.Stat.Total == 0 && len(pa.FailedFiltered) == 0 && len(pb.FailedFiltered) == 0 {
+			continue
+		}
+		pd := &PluginDiff{
+			Name:             name,
+			Total:            newCountDiff(pa.Stat.Total, pb.Stat.Total),
+			Passed:           newCountDiff(pa.Stat.Passed, pb.Stat.Passed),
+			Failed:           newCountDiff(pa.Stat.Failed, pb.Stat.Failed),
+			FailedFiltered:   newCountDiff(int64(len(pa.FailedFiltered)), int64(len(pb.FailedFiltered))),
+			NewFailures:      []string{},
+			ResolvedFailures: []string{},
+			StillFailing:     []string{},
+		}
+		failuresA := make(map[string]struct{}, len(pa.FailedFiltered))
+		for _, name := range pa.GetFailedFilteredNames() {
+			failuresA[name] = struct{}{}
+		}
+		for _, name := range pb.GetFailedFilteredNames() {
+			if _, ok := failuresA[name]; ok {
+				pd.StillFailing = append(pd.StillFailing, name)
+				delete(failuresA, name)
+				continue
+			}
+			pd.NewFailures = append(pd.NewFailures, name)
+		}
+		for name := range failuresA {
+			pd.ResolvedFailures = append(pd.ResolvedFailures, name)
+		}
+		sort.Strings(pd.NewFailures)
+		sort.Strings(pd.ResolvedFailures)
+		sort.Strings(pd.StillFailing)
+		diff.Plugins = append(diff.Plugins, pd)
+	}
+
+	checksA, checksB := getCheckResults(a), getCheckResults(b)
+	ids := []string{}
+	for id := range checksA {
+		ids = append(ids, id)
+	}
+	for id := range checksB {
+		if _, ok := checksA[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		cd := &CheckDiff{ID: id, A: CheckIdEmptyValue, B: CheckIdEmptyValue}
+		if check, ok := checksA[id]; ok {
+			cd.Name, cd.A = check.SLO, check.SLOResult
+		}
+		if check, ok := checksB[id]; ok {
+			cd.Name, cd.B = check.SLO, check.SLOResult
+		}
+		if cd.A == cd.B {
+			continue
+		}
+		diff.Checks = append(diff.Checks, cd)
+	}
+
+	countersA, countersB := getErrorCounters(a), getErrorCounters(b)
+	for _, source := range []string{DiffErrorSourceTests, DiffErrorSourceEtcd} {
+		names := []string{}
+		for name := range countersA[source] {
+			names = append(names, name)
+		}
+		for name := range countersB[source] {
+			if _, ok := countersA[source][name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cd := newCountDiff(int64(countersA[source][name]), int64(countersB[source][name]))
+			if cd.Delta == 0 {
+				continue
+			}
+			diff.ErrorCounters = append(diff.ErrorCounters, &ErrorCounterDiff{Source: source, Name: name, CountDiff: cd})
+		}
+	}
+
+	diff.Etcd = newEtcdDiff(a, b)
+	return diff
+}
+
+// HasChanges returns true when the reports have differences in the cluster, failures or checks.
+func (d *ReportDiff) HasChanges() bool {
+	if len(d.Checks) > 0 || len(d.Cluster) > 0 {
+		return true
+	}
+	for _, p := range d.Plugins {
+		if len(p.NewFailures) > 0 || len(p.ResolvedFailures) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// diffTable is a table of the diff with the title.
+type diffTable struct {
+	title string
+	table table.Writer
+}
+
+// tables builds the tables of the diff: cluster, plugin counters, failures changes, checks,
+// error counters and etcd latency.
+func (d *ReportDiff) tables() []*diffTable {
+	tbCluster := table.NewWriter()
+	tbCluster.AppendHeader(table.Row{"Field", d.NameA, d.NameB})
+	for _, f := range d.Cluster {
+		tbCluster.AppendRow(table.Row{f.Name, f.A, f.B})
+	}
+
+	tbCounts := table.NewWriter()
+	tbCounts.AppendHeader(table.Row{"Plugin", "Total", "Passed", "Failed", "Failed (filtered)", "New failures", "Resolved failures"})
+	for _, p := range d.Plugins {
+		tbCounts.AppendRow(table.Row{p.Name, p.Total, p.Passed, p.Failed, p.FailedFiltered, len(p.NewFailures), len(p.ResolvedFailures)})
+	}
+
+	tbFailures := table.NewWriter()
+	tbFailures.AppendHeader(table.Row{"Plugin", "Change", "Test"})
+	for _, p := range d.Plugins {
+		for _, name := range p.NewFailures {
+			tbFailures.AppendRow(table.Row{p.Name, "new", name})
+		}
+		for _, name := range p.ResolvedFailures {
+			tbFailures.AppendRow(table.Row{p.Name, "resolved", name})
+		}
+	}
+
+	tbChecks := table.NewWriter()
+	tbChecks.AppendHeader(table.Row{"ID", "Check", d.NameA, d.NameB})
+	for _, c := range d.Checks {
+		tbChecks.AppendRow(table.Row{c.ID, c.Name, c.A, c.B})
+	}
+
+	tbCounters := table.NewWriter()
+	tbCounters.AppendHeader(table.Row{"Source", "Error", "Count"})
+	for _, c := range d.ErrorCounters {
+		tbCounters.AppendRow(table.Row{c.Source, c.Name, c.CountDiff})
+	}
+
+	tbEtcd := table.NewWriter()
+	tbEtcd.AppendHeader(table.Row{"Metric", "Value"})
+	if d.Etcd != nil {
+		tbEtcd.AppendRow(table.Row{"Slow requests", d.Etcd.SlowRequests})
+		if d.Etcd.Mean != nil {
+			tbEtcd.AppendRow(table.Row{"Slow requests latency (mean)", d.Etcd.Mean})
+		}
+		if d.Etcd.P99 != nil {
+			tbEtcd.AppendRow(table.Row{"Slow requests latency (p99)", d.Etcd.P99})
+		}
+	}
+	return []*diffTable{
+		{title: "Cluster changed", table: tbCluster},
+		{title: fmt.Sprintf("Plugin results: %s -> %s", d.NameA, d.NameB), table: tbCounts},
+		{title: "Failures changed", table: tbFailures},
+		{title: "Checks changed", table: tbChecks},
+		{title: "Error counters changed", table: tbCounters},
+		{title: "etcd slow requests", table: tbEtcd},
+	}
+}
+
+// RenderTable writes the diff as CLI tables.
+func (d *ReportDiff) RenderTable(w io.Writer) {
+	for _, dt := range d.tables() {
+		dt.table.SetTitle(dt.title)
+		fmt.Fprintln(w, dt.table.Render())
+		fmt.Fprintln(w)
+	}
+}
+
+// RenderMarkdown writes the diff as Markdown tables.
+func (d *ReportDiff) RenderMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "## Diff: %s -> %s\n\n", d.NameA, d.NameB)
+	for _, dt := range d.tables() {
+		fmt.Fprintf(w, "### %s\n\n", dt.title)
+		if dt.table.Length() == 0 {
+			fmt.Fprint(w, "No changes.\n\n")
+			continue
+		}
+		fmt.Fprintf(w, "%s\n\n", dt.table.RenderMarkdown())
+	}
+}
+
+// RenderHTML writes the diff as a HTML page, rendered from the template diff.html.
+func (d *ReportDiff) RenderHTML(w io.Writer) error {
+	src := fmt.Sprintf("%s/%s", ReportTemplateBasePath, DiffTemplateHTML)
+	data, err := vfs.GetData().ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("unable to read file %q from VFS: %v", src, err)
+	}
+	return d.renderHTML(w, data)
+}
+
+func (d *ReportDiff) renderHTML(w io.Writer, tmplData []byte) error {
+	// Same template delimiter of the report pages.
+	tmpl, err := template.New("diff").Delims("[[", "]]").Parse(string(tmplData))
+	if err != nil {
+		return fmt.Errorf("unable to create template for the diff: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return fmt.Errorf("unable to process template for the diff: %v", err)
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,130 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/archive"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+)
+
+func newDiffReport(total, failed int64, failures []string, checks map[string]string) *ReportData {
+	re := &ReportData{
+		Provider: &ReportResult{
+			Plugins: map[string]*ReportPlugin{
+				plugin.PluginNameOpenShiftConformance: {
+					Stat: &ReportPluginStat{Total: total, Passed: total - failed, Failed: failed},
+				},
+			},
+		},
+		Checks: &ReportChecks{},
+	}
+	for _, name := range failures {
+		p := re.Provider.Plugins[plugin.PluginNameOpenShiftConformance]
+		p.FailedFiltered = append(p.FailedFiltered, &ReportTestFailure{Name: name})
+	}
+	for id, result := range checks {
+		out := &SLOOutput{ID: id, SLO: "check " + id, SLOResult: result}
+		switch CheckResultName(result) {
+		case CheckResultNameFail:
+			re.Checks.Fail = append(re.Checks.Fail, out)
+		case CheckResultNameWarn:
+			re.Checks.Warn = append(re.Checks.Warn, out)
+		default:
+			re.Checks.Pass = append(re.Checks.Pass, out)
+		}
+	}
+	return re
+}
+
+func TestNewReportDiff(t *testing.T) {
+	a := newDiffReport(100, 3, []string{"t1", "t2", "t3"}, map[string]string{"OPCT-001": "pass", "OPCT-004": "fail", "OPCT-005": "pass"})
+	b := newDiffReport(110, 2, []string{"t2", "t4"}, map[string]string{"OPCT-001": "pass", "OPCT-004": "pass", "OPCT-022": "warn"})
+
+	diff := NewReportDiff("a", a, "b", b)
+	assert.True(t, diff.HasChanges())
+	assert.Len(t, diff.Plugins, 1)
+	pd := diff.Plugins[0]
+	assert.Equal(t, plugin.PluginNameOpenShiftConformance, pd.Name)
+	assert.Equal(t, &CountDiff{A: 100, B: 110, Delta: 10}, pd.Total)
+	assert.Equal(t, int64(-1), pd.FailedFiltered.Delta)
+	assert.Equal(t, []string{"t4"}, pd.NewFailures)
+	assert.Equal(t, []string{"t1", "t3"}, pd.ResolvedFailures)
+	assert.Equal(t, []string{"t2"}, pd.StillFailing)
+
+	assert.Equal(t, []*CheckDiff{
+		{ID: "OPCT-004", Name: "check OPCT-004", A: "fail", B: "pass"},
+		{ID: "OPCT-005", Name: "check OPCT-005", A: "pass", B: CheckIdEmptyValue},
+		{ID: "OPCT-022", Name: "check OPCT-022", A: CheckIdEmptyValue, B: "warn"},
+	}, diff.Checks)
+
+	assert.False(t, NewReportDiff("a", a, "a", a).HasChanges())
+	assert.Empty(t, NewReportDiff("a", &ReportData{}, "b", &ReportData{}).Plugins)
+}
+
+func TestReportDiffRender(t *testing.T) {
+	a := newDiffReport(100, 1, []string{"t1"}, map[string]string{"OPCT-004": "fail"})
+	b := newDiffReport(100, 1, []string{"t2"}, map[string]string{"OPCT-004": "pass"})
+	diff := NewReportDiff("a", a, "b", b)
+
+	buf := &bytes.Buffer{}
+	diff.RenderTable(buf)
+	assert.Contains(t, buf.String(), "Plugin results: a -> b")
+	assert.Contains(t, buf.String(), "t2")
+
+	buf.Reset()
+	diff.RenderMarkdown(buf)
+	assert.Contains(t, buf.String(), "## Diff: a -> b")
+	assert.Contains(t, buf.String(), "| 20-openshift-conformance-validated | new | t2 |")
+	assert.Contains(t, buf.String(), "| OPCT-004 | check OPCT-004 | fail | pass |")
+
+	buf.Reset()
+	NewReportDiff("a", a, "a", a).RenderMarkdown(buf)
+	assert.Contains(t, buf.String(), "No changes.")
+}
+
+func TestReportDiffClusterAndCounters(t *testing.T) {
+	newReport := func(version string, counters archive.ErrorCounter, etcd archive.ErrorCounter, p99 string) *ReportData {
+		re := newDiffReport(100, 0, nil, nil)
+		re.Provider.Version = &ReportVersion{OpenShift: &summary.SummaryClusterVersionOutput{Desired: version}, Kubernetes: "1.29"}
+		re.Provider.Infra = &ReportInfra{PlatformType: "External"}
+		re.Provider.ErrorCounters = &counters
+		re.Provider.MustGatherInfo = &mustgather.MustGather{
+			ErrorEtcdLogs: &mustgather.ErrorEtcdLogs{
+				ErrorCounters: etcd,
+				FilterRequestSlowAll: map[string]*mustgather.BucketFilterStat{
+					"all": {RequestCount: 10, StatMean: "100.000 (ms)", StatPerc99: p99},
+				},
+			},
+		}
+		return re
+	}
+	a := newReport("4.16.0", archive.ErrorCounter{"e2e timeout": 2, "failed": 1}, archive.ErrorCounter{"apply request took too long": 5}, "500.000 (ms)")
+	b := newReport("4.16.1", archive.ErrorCounter{"e2e timeout": 3, "failed": 1}, archive.ErrorCounter{}, "750.500 (ms)")
+
+	diff := NewReportDiff("a", a, "b", b)
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, []*FieldDiff{{Name: "OpenShift version", A: "4.16.0", B: "4.16.1"}}, diff.Cluster)
+	assert.Equal(t, []*ErrorCounterDiff{
+		{Source: DiffErrorSourceTests, Name: "e2e timeout", CountDiff: &CountDiff{A: 2, B: 3, Delta: 1}},
+		{Source: DiffErrorSourceEtcd, Name: "apply request took too long", CountDiff: &CountDiff{A: 5, B: 0, Delta: -5}},
+	}, diff.ErrorCounters)
+	assert.Equal(t, int64(0), diff.Etcd.SlowRequests.Delta)
+	assert.Equal(t, "100.000 ms", diff.Etcd.Mean.String())
+	assert.Equal(t, "500.000 -> 750.500 (+250.500) ms", diff.Etcd.P99.String())
+
+	assert.Nil(t, NewReportDiff("a", &ReportData{}, "b", &ReportData{}).Etcd)
+
+	tmpl, err := os.ReadFile("../../data/templates/report/" + DiffTemplateHTML)
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, diff.renderHTML(buf, tmpl))
+	assert.Contains(t, buf.String(), "<td>OpenShift version</td><td>4.16.0</td><td>4.16.1</td>")
+	assert.Contains(t, buf.String(), "<td>tests</td><td>e2e timeout</td><td>2 -&gt; 3 (&#43;1)</td>")
+	assert.Contains(t, buf.String(), "500.000 -&gt; 750.500 (&#43;250.500) ms")
+}
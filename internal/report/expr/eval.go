@@ -0,0 +1,536 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type evaluator struct {
+	env Env
+
+	// cache holds the lazy values evaluated.
+	cache map[string]interface{}
+}
+
+// function is a builtin function, called with the evaluated arguments.
+type function func(args []interface{}) (interface{}, error)
+
+// functions are the builtin functions. The functions 'has' and 'default' are
+// evaluated by the evaluator, as the arguments may fail to evaluate.
+var functions = map[string]function{
+	"has":     nil,
+	"default": nil,
+	"len":     fnLen,
+	"float":   fnFloat,
+	"int":     fnInt,
+	"string":  fnString,
+	"sprintf": fnSprintf,
+	"split":   fnSplit,
+	"contains": func(args []interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("contains: want 2 arguments, got %d", len(args))
+		}
+		if s, ok := args[0].(string); ok {
+			return strings.Contains(s, toString(args[1])), nil
+		}
+		items, err := toList(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("contains: %w", err)
+		}
+		for _, item := range items {
+			if eq, err := equals(item, args[1]); err == nil && eq {
+				return true, nil
+			}
+		}
+		return false, nil
+	},
+	"find": fnFind,
+	"lower": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("lower: want 1 argument, got %d", len(args))
+		}
+		return strings.ToLower(toString(args[0])), nil
+	},
+	"upper": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("upper: want 1 argument, got %d", len(args))
+		}
+		return strings.ToUpper(toString(args[0])), nil
+	},
+}
+
+func (ev *evaluator) eval(n node) (interface{}, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *identNode:
+		return ev.lookup(n.name)
+	case *memberNode:
+		obj, err := ev.eval(n.obj)
+		if err != nil {
+			return nil, err
+		}
+		return member(obj, n.name)
+	case *indexNode:
+		obj, err := ev.eval(n.obj)
+		if err != nil {
+			return nil, err
+		}
+		index, err := ev.eval(n.index)
+		if err != nil {
+			return nil, err
+		}
+		return indexValue(obj, index)
+	case *listNode:
+		items := make([]interface{}, 0, len(n.items))
+		for _, item := range n.items {
+			v, err := ev.eval(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	case *callNode:
+		return ev.call(n)
+	case *unaryNode:
+		v, err := ev.eval(n.operand)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "!" {
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("operator !: want bool, got %s", typeName(v))
+			}
+			return !b, nil
+		}
+		f, err := toNumber(v)
+		if err != nil {
+			return nil, fmt.Errorf("operator -: %w", err)
+		}
+		return -f, nil
+	case *binaryNode:
+		return ev.binary(n)
+	}
+	return nil, fmt.Errorf("unknown expression node %T", n)
+}
+
+func (ev *evaluator) lookup(name string) (interface{}, error) {
+	if v, ok := ev.cache[name]; ok {
+		return v, nil
+	}
+	v, ok := ev.env[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier %q", name)
+	}
+	if lazy, ok := v.(Lazy); ok {
+		lv, err := lazy()
+		if err != nil {
+			return nil, err
+		}
+		v = lv
+	}
+	v = normalize(v)
+	ev.cache[name] = v
+	return v, nil
+}
+
+func (ev *evaluator) call(n *callNode) (interface{}, error) {
+	switch n.name {
+	case "has":
+		if len(n.args) != 1 {
+			return nil, fmt.Errorf("has: want 1 argument, got %d", len(n.args))
+		}
+		v, err := ev.eval(n.args[0])
+		return err == nil && v != nil, nil
+	case "default":
+		if len(n.args) != 2 {
+			return nil, fmt.Errorf("default: want 2 arguments, got %d", len(n.args))
+		}
+		if v, err := ev.eval(n.args[0]); err == nil && v != nil {
+			return v, nil
+		}
+		return ev.eval(n.args[1])
+	}
+	args := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		v, err := ev.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	return functions[n.name](args)
+}
+
+func (ev *evaluator) binary(n *binaryNode) (interface{}, error) {
+	left, err := ev.eval(n.left)
+	if err != nil {
+		return nil, err
+	}
+	// logical operators short-circuit.
+	if n.op == "&&" || n.op == "||" {
+		lb, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s: want bool, got %s", n.op, typeName(left))
+		}
+		if (n.op == "&&" && !lb) || (n.op == "||" && lb) {
+			return lb, nil
+		}
+		right, err := ev.eval(n.right)
+		if err != nil {
+			return nil, err
+		}
+		rb, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s: want bool, got %s", n.op, typeName(right))
+		}
+		return rb, nil
+	}
+	right, err := ev.eval(n.right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==", "!=":
+		eq, err := equals(left, right)
+		if err != nil {
+			return nil, err
+		}
+		return eq == (n.op == "=="), nil
+	case "+":
+		// string concatenation when any operand is string.
+		ls, lok := left.(string)
+		rs, rok := right.(string)
+		if lok || rok {
+			if !lok {
+				ls = toString(left)
+			}
+			if !rok {
+				rs = toString(right)
+			}
+			return ls + rs, nil
+		}
+	case "<", "<=", ">", ">=":
+		ls, lok := left.(string)
+		rs, rok := right.(string)
+		if lok && rok {
+			return compare(n.op, strings.Compare(ls, rs)), nil
+		}
+	}
+
+	lf, err := toNumber(left)
+	if err != nil {
+		return nil, fmt.Errorf("operator %s: %w", n.op, err)
+	}
+	rf, err := toNumber(right)
+	if err != nil {
+		return nil, fmt.Errorf("operator %s: %w", n.op, err)
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, fmt.Errorf("operator /: division by zero")
+		}
+		return lf / rf, nil
+	case "%":
+		if rf == 0 {
+			return nil, fmt.Errorf("operator %%: division by zero")
+		}
+		return math.Mod(lf, rf), nil
+	}
+	cmp := 0
+	if lf < rf {
+		cmp = -1
+	} else if lf > rf {
+		cmp = 1
+	}
+	return compare(n.op, cmp), nil
+}
+
+func compare(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// normalize converts the value to the types handled by the expressions: numbers
+// are float64, named strings are string, and pointers are dereferenced.
+func normalize(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Map, reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+	}
+	return rv.Interface()
+}
+
+// member returns the field of the struct, by json tag or name, or the map key.
+func member(obj interface{}, name string) (interface{}, error) {
+	if obj == nil {
+		return nil, fmt.Errorf("field %q: value is null", name)
+	}
+	rv := reflect.ValueOf(obj)
+	switch rv.Kind() {
+	case reflect.Map:
+		return indexValue(obj, name)
+	case reflect.Struct:
+		// match the json tag, falling back to the case-insensitive tag or field name.
+		rt := rv.Type()
+		fallback := -1
+		for i := 0; i < rt.NumField(); i++ {
+			f := rt.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag := strings.Split(f.Tag.Get("json"), ",")[0]
+			if tag == "-" {
+				continue
+			}
+			if tag == name {
+				return normalize(rv.Field(i).Interface()), nil
+			}
+			if fallback < 0 && (strings.EqualFold(tag, name) || (tag == "" && strings.EqualFold(f.Name, name))) {
+				fallback = i
+			}
+		}
+		if fallback >= 0 {
+			return normalize(rv.Field(fallback).Interface()), nil
+		}
+	}
+	return nil, fmt.Errorf("field %q not found in %s", name, typeName(obj))
+}
+
+// indexValue returns the map value by key, or the list item by index.
+func indexValue(obj, index interface{}) (interface{}, error) {
+	if obj == nil {
+		return nil, fmt.Errorf("index %v: value is null", index)
+	}
+	rv := reflect.ValueOf(obj)
+	switch rv.Kind() {
+	case reflect.Map:
+		key := reflect.ValueOf(toString(index))
+		if !key.Type().ConvertibleTo(rv.Type().Key()) {
+			return nil, fmt.Errorf("index %v: unsupported map key type %s", index, rv.Type().Key())
+		}
+		v := rv.MapIndex(key.Convert(rv.Type().Key()))
+		if !v.IsValid() {
+			return nil, fmt.Errorf("key %q not found", toString(index))
+		}
+		return normalize(v.Interface()), nil
+	case reflect.Slice, reflect.Array:
+		f, err := toNumber(index)
+		if err != nil {
+			return nil, fmt.Errorf("list index: %w", err)
+		}
+		i := int(f)
+		if i < 0 || i >= rv.Len() {
+			return nil, fmt.Errorf("list index %d out of range [0:%d]", i, rv.Len())
+		}
+		return normalize(rv.Index(i).Interface()), nil
+	}
+	return nil, fmt.Errorf("index %v: want map or list, got %s", index, typeName(obj))
+}
+
+func equals(a, b interface{}) (bool, error) {
+	if a == nil || b == nil {
+		return a == nil && b == nil, nil
+	}
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return av == bv, nil
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return av == bv, nil
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			return av == bv, nil
+		}
+	}
+	return false, fmt.Errorf("unable to compare %s with %s", typeName(a), typeName(b))
+}
+
+func toNumber(v interface{}) (float64, error) {
+	switch n := normalize(v).(type) {
+	case float64:
+		return n, nil
+	}
+	return 0, fmt.Errorf("want number, got %s", typeName(v))
+}
+
+func toList(v interface{}) ([]interface{}, error) {
+	if v == nil {
+		return []interface{}{}, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("want list, got %s", typeName(v))
+	}
+	items := make([]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		items = append(items, normalize(rv.Index(i).Interface()))
+	}
+	return items, nil
+}
+
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	}
+	return reflect.TypeOf(v).String()
+}
+
+func fnLen(args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("len: want 1 argument, got %d", len(args))
+	}
+	if args[0] == nil {
+		return float64(0), nil
+	}
+	rv := reflect.ValueOf(args[0])
+	switch rv.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		return float64(rv.Len()), nil
+	}
+	return nil, fmt.Errorf("len: unsupported type %s", typeName(args[0]))
+}
+
+func fnFloat(args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("float: want 1 argument, got %d", len(args))
+	}
+	if s, ok := args[0].(string); ok {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("float: %w", err)
+		}
+		return f, nil
+	}
+	f, err := toNumber(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("float: %w", err)
+	}
+	return f, nil
+}
+
+// fnInt converts the value to an integer, used by sprintf %d verb.
+func fnInt(args []interface{}) (interface{}, error) {
+	f, err := fnFloat(args)
+	if err != nil {
+		return nil, err
+	}
+	return int64(f.(float64)), nil
+}
+
+func fnString(args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("string: want 1 argument, got %d", len(args))
+	}
+	return toString(args[0]), nil
+}
+
+func fnSprintf(args []interface{}) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("sprintf: want at least 1 argument")
+	}
+	format, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("sprintf: want format string, got %s", typeName(args[0]))
+	}
+	return fmt.Sprintf(format, args[1:]...), nil
+}
+
+func fnSplit(args []interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("split: want 2 arguments, got %d", len(args))
+	}
+	parts := strings.Split(toString(args[0]), toString(args[1]))
+	items := make([]interface{}, 0, len(parts))
+	for _, p := range parts {
+		items = append(items, p)
+	}
+	return items, nil
+}
+
+// fnFind returns the first item of the list which field is equal to the value.
+// Example: find(plugin.filters, "id", "flaky")
+func fnFind(args []interface{}) (interface{}, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("find: want 3 arguments, got %d", len(args))
+	}
+	items, err := toList(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("find: %w", err)
+	}
+	field := toString(args[1])
+	for _, item := range items {
+		v, err := member(item, field)
+		if err != nil {
+			continue
+		}
+		if eq, err := equals(v, args[2]); err == nil && eq {
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("find: no item with %s == %v", field, toString(args[2]))
+}
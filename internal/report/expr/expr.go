@@ -0,0 +1,123 @@
+// Package expr implements a small expression language evaluated over Go values,
+// used by the declarative checks of the report (rules).
+//
+// Expressions support:
+// - literals: numbers (float64), strings ("..." or '...'), true, false, null, and lists [a, b]
+// - fields: roots from the environment, and members resolved by the json tag of
+// structs, map keys and list indexes: provider.plugins["10-openshift-kube-conformance"].stat.total
+// - operators, from the lowest precedence: ||, &&, == !=, < <= > >=, + -, * / %, unary ! -
+// - functions: len, has, default, float, int, string, sprintf, split, contains, find, lower, upper.
+//
+// Example: has(provider.clusterHealth) && provider.clusterHealth.podHealthPerc >= 98
+package expr
+
+import (
+	"fmt"
+)
+
+// Env is the environment of the expression evaluation, mapping the root
+// identifiers to values. Values of type Lazy are evaluated on the first use.
+type Env map[string]interface{}
+
+// Lazy is a value evaluated when referenced by the expression.
+type Lazy func() (interface{}, error)
+
+// Expression is a compiled expression.
+type Expression struct {
+	src  string
+	root node
+}
+
+// Compile parses the expression.
+func Compile(src string) (*Expression, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.next(); err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid expression %q: unexpected %s at position %d", src, p.tok, p.tok.pos)
+	}
+	return &Expression{src: src, root: root}, nil
+}
+
+// MustCompile parses the expression, panicking when the expression is invalid.
+func MustCompile(src string) *Expression {
+	e, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression in the environment.
+func (e *Expression) Eval(env Env) (interface{}, error) {
+	ev := &evaluator{env: env, cache: make(map[string]interface{})}
+	return ev.eval(e.root)
+}
+
+// EvalBool evaluates the expression, which result must be a boolean.
+func (e *Expression) EvalBool(env Env) (bool, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q: want bool, got %s", e.src, typeName(v))
+	}
+	return b, nil
+}
+
+// EvalString evaluates the expression, converting the result to string.
+func (e *Expression) EvalString(env Env) (string, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return "", err
+	}
+	return toString(v), nil
+}
+
+// Identifiers returns the root identifiers referenced by the expression.
+func (e *Expression) Identifiers() []string {
+	seen := map[string]struct{}{}
+	ids := []string{}
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *identNode:
+			if _, ok := seen[n.name]; !ok {
+				seen[n.name] = struct{}{}
+				ids = append(ids, n.name)
+			}
+		case *memberNode:
+			walk(n.obj)
+		case *indexNode:
+			walk(n.obj)
+			walk(n.index)
+		case *listNode:
+			for _, item := range n.items {
+				walk(item)
+			}
+		case *callNode:
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		case *unaryNode:
+			walk(n.operand)
+		case *binaryNode:
+			walk(n.left)
+			walk(n.right)
+		}
+	}
+	walk(e.root)
+	return ids
+}
@@ -0,0 +1,121 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStat struct {
+	Total  int64 `json:"total"`
+	Failed int64 `json:"failed"`
+}
+
+type testPlugin struct {
+	Name     string            `json:"name"`
+	Stat     *testStat         `json:"stat"`
+	Failures []string          `json:"failures,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Status   string
+}
+
+func TestEval(t *testing.T) {
+	env := Env{
+		"plugins": map[string]*testPlugin{
+			"10-kube": {Name: "kube", Stat: &testStat{Total: 400, Failed: 4}, Failures: []string{"a", "b"}, Status: "passed"},
+			"20-ocp":  {Name: "ocp", Stat: &testStat{Total: 3000}, Labels: map[string]string{"topology.kubernetes.io/zone": "a"}},
+		},
+		"thresholds": map[string]float64{"max": 1.5},
+		"missing":    (*testPlugin)(nil),
+		"lazy":       Lazy(func() (interface{}, error) { return 42, nil }),
+	}
+	cases := []struct {
+		expr string
+		want interface{}
+	}{
+		{`1 + 2 * 3`, float64(7)},
+		{`(1 + 2) * 3`, float64(9)},
+		{`-2 + 10 % 4`, float64(0)},
+		{`plugins["10-kube"].stat.total`, float64(400)},
+		{`plugins["10-kube"].stat.failed / plugins["10-kube"].stat.total * 100 > thresholds.max`, false},
+		{`len(plugins["10-kube"].failures) == 2 && plugins["10-kube"].failures[1] == "b"`, true},
+		{`plugins["10-kube"].Status == "passed"`, true},
+		{`plugins["10-kube"].status == 'passed'`, true},
+		{`plugins["20-ocp"].labels["topology.kubernetes.io/zone"]`, "a"},
+		{`len(plugins["20-ocp"].failures)`, float64(0)},
+		{`has(plugins["30-none"])`, false},
+		{`has(missing) || !has(missing.stat)`, true},
+		{`default(plugins["30-none"].stat.total, -1)`, float64(-1)},
+		{`"Total==" + plugins["20-ocp"].stat.total`, "Total==3000"},
+		{`sprintf("%.2f%%(%d)", 1.5, int(4))`, "1.50%(4)"},
+		{`sprintf("Failed%v", ["10", "20"])`, "Failed[10 20]"},
+		{`float(split("123.456 (ms)", " ")[0]) >= 100`, true},
+		{`contains(["None", "External"], "External") && !contains("HighlyAvailable", "Single")`, true},
+		{`lazy + 1`, float64(43)},
+		{`"a" < "b" && null == null && upper(lower("X")) == "X"`, true},
+		{`find([plugins["10-kube"], plugins["20-ocp"]], "name", "ocp").stat.total`, float64(3000)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := Compile(tc.expr)
+			assert.NoError(t, err)
+			got, err := e.Eval(env)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	env := Env{"v": map[string]interface{}{"n": 1, "s": "x"}}
+	for _, src := range []string{
+		`unknown`,
+		`v.n / 0`,
+		`v.missing`,
+		`v.s + 1 > 2`,
+		`v.n && true`,
+		`v.n == "1"`,
+		`v.s[0]`,
+	} {
+		e, err := Compile(src)
+		assert.NoError(t, err, src)
+		_, err = e.Eval(env)
+		assert.Error(t, err, src)
+	}
+	for _, src := range []string{`1 +`, `foo(1)`, `"open`, `a.`, `(1`, `1 2`, `a # b`} {
+		_, err := Compile(src)
+		assert.Error(t, err, src)
+	}
+
+	_, err := MustCompile(`v.n`).EvalBool(env)
+	assert.Error(t, err)
+	s, err := MustCompile(`v.n * 1.5`).EvalString(env)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", s)
+}
+
+func TestTemplate(t *testing.T) {
+	env := Env{"thresholds": map[string]float64{"max": 1.5}, "zones": []string{"a", "b"}}
+	cases := []struct {
+		src  string
+		want string
+		ids  []string
+	}{
+		{"Pass>=${100 - thresholds.max}%", "Pass>=98.5%", []string{"thresholds"}},
+		{"Zones==${len(zones)} of ${thresholds.max}", "Zones==2 of 1.5", []string{"zones", "thresholds"}},
+		{"passed", "passed", []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.src, func(t *testing.T) {
+			tpl, err := CompileTemplate(tc.src)
+			assert.NoError(t, err)
+			got, err := tpl.Eval(env)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.ids, tpl.Identifiers())
+		})
+	}
+
+	_, err := CompileTemplate("Zones==${len(zones)")
+	assert.Error(t, err)
+}
@@ -0,0 +1,319 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators sorted by length, longest first.
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"<", ">", "+", "-", "*", "/", "%", "!", "(", ")", "[", "]", ".", ",",
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case c >= '0' && c <= '9':
+		for l.pos < len(l.src) && (l.src[l.pos] >= '0' && l.src[l.pos] <= '9' || l.src[l.pos] == '.') {
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: l.src[start:l.pos], pos: start}, nil
+	case c == '"' || c == '\'':
+		sb := strings.Builder{}
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != c {
+			if l.src[l.pos] == '\\' && l.pos+1 < len(l.src) {
+				l.pos++
+			}
+			sb.WriteByte(l.src[l.pos])
+			l.pos++
+		}
+		if l.pos >= len(l.src) {
+			return token{}, fmt.Errorf("unterminated string at position %d", start)
+		}
+		l.pos++
+		return token{kind: tokenString, text: sb.String(), pos: start}, nil
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || unicode.IsLetter(rune(l.src[l.pos])) || unicode.IsDigit(rune(l.src[l.pos]))) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.src[start:l.pos], pos: start}, nil
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokenOp, text: op, pos: start}, nil
+		}
+	}
+	return token{}, fmt.Errorf("unexpected character %q at position %d", c, start)
+}
+
+// AST nodes.
+type node interface{}
+
+type literalNode struct{ value interface{} }
+type identNode struct{ name string }
+type memberNode struct {
+	obj  node
+	name string
+}
+type indexNode struct{ obj, index node }
+type listNode struct{ items []node }
+type callNode struct {
+	name string
+	args []node
+}
+type unaryNode struct {
+	op      string
+	operand node
+}
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+// binary operators by precedence, from the lowest.
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) isOp(ops ...string) bool {
+	if p.tok.kind != tokenOp {
+		return false
+	}
+	for _, op := range ops {
+		if p.tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.isOp(op) {
+		return fmt.Errorf("want %q, got %s at position %d", op, p.tok, p.tok.pos)
+	}
+	return p.next()
+}
+
+func (p *parser) parseExpr() (node, error) {
+	return p.parseBinary(0)
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level >= len(precedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp(precedence[level]...) {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOp("!", "-") {
+		op := p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOp("."):
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokenIdent {
+				return nil, fmt.Errorf("want field name, got %s at position %d", p.tok, p.tok.pos)
+			}
+			n = &memberNode{obj: n, name: p.tok.text}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		case p.isOp("["):
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = &indexNode{obj: n, index: index}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// parseList parses the items until the closing operator.
+func (p *parser) parseList(closing string) ([]node, error) {
+	items := []node{}
+	for !p.isOp(closing) {
+		item, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.isOp(",") {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(closing); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return &literalNode{value: v}, p.next()
+	case tokenString:
+		return &literalNode{value: tok.text}, p.next()
+	case tokenIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if !p.isOp("(") {
+			return &identNode{name: tok.text}, nil
+		}
+		if _, ok := functions[tok.text]; !ok {
+			return nil, fmt.Errorf("unknown function %q at position %d", tok.text, tok.pos)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		args, err := p.parseList(")")
+		if err != nil {
+			return nil, err
+		}
+		return &callNode{name: tok.text, args: args}, nil
+	case tokenOp:
+		switch tok.text {
+		case "(":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		case "[":
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{items: items}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos)
+}
@@ -0,0 +1,78 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Template is a text with interpolated expressions: "Zones==${len(zones)}".
+type Template struct {
+	src   string
+	parts []templatePart
+}
+
+type templatePart struct {
+	text string
+	expr *Expression
+}
+
+// CompileTemplate parses the expressions ${...} of the template.
+func CompileTemplate(src string) (*Template, error) {
+	t := &Template{src: src}
+	rest := src
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			if rest != "" {
+				t.parts = append(t.parts, templatePart{text: rest})
+			}
+			return t, nil
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid template %q: unterminated ${", src)
+		}
+		if start > 0 {
+			t.parts = append(t.parts, templatePart{text: rest[:start]})
+		}
+		e, err := Compile(rest[start+2 : start+end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %q: %w", src, err)
+		}
+		t.parts = append(t.parts, templatePart{expr: e})
+		rest = rest[start+end+1:]
+	}
+}
+
+// String returns the source of the template.
+func (t *Template) String() string {
+	return t.src
+}
+
+// Eval renders the template in the environment.
+func (t *Template) Eval(env Env) (string, error) {
+	sb := strings.Builder{}
+	for _, part := range t.parts {
+		if part.expr == nil {
+			sb.WriteString(part.text)
+			continue
+		}
+		s, err := part.expr.EvalString(env)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s)
+	}
+	return sb.String(), nil
+}
+
+// Identifiers returns the root identifiers referenced by the expressions of the template.
+func (t *Template) Identifiers() []string {
+	ids := []string{}
+	for _, part := range t.parts {
+		if part.expr != nil {
+			ids = append(ids, part.expr.Identifiers()...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,23 @@
+package report
+
+import (
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+)
+
+// SetFailureHistory annotates the failures to review (after the filter pipeline)
+// with the failure history of the test across the baselines.
+func (re *ReportData) SetFailureHistory(fh *baseline.FailureHistory) {
+	if re.Provider == nil || fh == nil {
+		return
+	}
+	for _, pluginName := range plugin.ConformancePlugins {
+		p, ok := re.Provider.Plugins[pluginName]
+		if !ok {
+			continue
+		}
+		for _, failure := range p.FailedFiltered {
+			failure.History = fh.Get(failure.Name)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFailureHistory(t *testing.T) {
+	re := &ReportData{Provider: &ReportResult{Plugins: map[string]*ReportPlugin{
+		plugin.PluginNameOpenShiftConformance: {
+			FailedFiltered: []*ReportTestFailure{{Name: "t1"}, {Name: "t2"}},
+		},
+	}}}
+	fh := baseline.NewFailureHistory("4.15", "None", []string{"b1", "b2"}, []map[string][]string{
+		{plugin.PluginNameOpenShiftConformance: {"t1"}},
+		{plugin.PluginNameOpenShiftConformance: {"t1"}},
+	})
+	re.SetFailureHistory(fh)
+
+	failures := re.Provider.Plugins[plugin.PluginNameOpenShiftConformance].FailedFiltered
+	assert.Equal(t, "2/2 #1", failures[0].History.String())
+	assert.Equal(t, "FF", failures[0].History.Timeline)
+	assert.Nil(t, failures[1].History)
+
+	// no-op without provider data
+	(&ReportData{}).SetFailureHistory(fh)
+}
@@ -0,0 +1,161 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// JUnitSuiteChecks is the name of the JUnit testsuite of the checks.
+const JUnitSuiteChecks = "opct-checks"
+
+// JUnitTestSuites is the root element of the JUnit XML ('opct report --junit').
+type JUnitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Suites   []*JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is a testsuite, created for the checks and for each plugin.
+type JUnitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	TestCases []*JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a check, or a failed test remaining after the filter pipeline.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitFailure is the failure of the test case.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// JUnitSkipped is the reason of the skipped test case.
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// NewJUnit builds the JUnit test suites from the report: one testsuite for the
+// checks, and one testsuite for each plugin listing the failures after filters.
+// Checks in warning are reported as passed, with the message in the system-out,
+// and waived checks are reported as skipped with the waiver.
+func NewJUnit(re *ReportData) *JUnitTestSuites {
+	suites := &JUnitTestSuites{Name: "opct"}
+	if re.Checks != nil {
+		suites.add(newJUnitChecksSuite(re.Checks))
+	}
+	if re.Provider != nil {
+		plugins := make([]string, 0, len(re.Provider.Plugins))
+		for name := range re.Provider.Plugins {
+			plugins = append(plugins, name)
+		}
+		sort.Strings(plugins)
+		for _, name := range plugins {
+			suites.add(newJUnitPluginSuite(name, re.Provider.Plugins[name]))
+		}
+	}
+	return suites
+}
+
+func (ts *JUnitTestSuites) add(suite *JUnitTestSuite) {
+	ts.Suites = append(ts.Suites, suite)
+	ts.Tests += suite.Tests
+	ts.Failures += suite.Failures
+	ts.Skipped += suite.Skipped
+}
+
+func (s *JUnitTestSuite) add(tc *JUnitTestCase) {
+	s.TestCases = append(s.TestCases, tc)
+	s.Tests++
+	if tc.Failure != nil {
+		s.Failures++
+	}
+	if tc.Skipped != nil {
+		s.Skipped++
+	}
+}
+
+func newJUnitChecksSuite(checks *ReportChecks) *JUnitTestSuite {
+	suite := &JUnitTestSuite{Name: JUnitSuiteChecks, TestCases: []*JUnitTestCase{}}
+	for _, group := range []struct {
+		result  CheckResultName
+		outputs []*SLOOutput
+	}{
+		{CheckResultNameFail, checks.Fail},
+		{CheckResultNameWarn, checks.Warn},
+		{CheckResultNameWaived, checks.Waived},
+		{CheckResultNameSkip, checks.Skip},
+		{CheckResultNamePass, checks.Pass},
+	} {
+		for _, check := range group.outputs {
+			tc := &JUnitTestCase{Name: check.SLO, Classname: JUnitSuiteChecks}
+			if check.ID != CheckIdEmptyValue {
+				tc.Name = fmt.Sprintf("[%s] %s", check.ID, check.SLO)
+			}
+			msg := fmt.Sprintf("want=%q, got=%q", check.SLITarget, check.SLIActual)
+			switch group.result {
+			case CheckResultNameFail:
+				tc.Failure = &JUnitFailure{Message: msg, Content: check.Message}
+			case CheckResultNameWaived:
+				tc.Skipped = &JUnitSkipped{Message: string(CheckResultNameWaived)}
+				if w := check.Waiver; w != nil {
+					tc.Skipped.Message = fmt.Sprintf("waived by %s until %s: %s", w.Approver, w.Expires, w.Justification)
+				}
+				tc.SystemOut = fmt.Sprintf("%s\n%s", msg, check.Message)
+			case CheckResultNameSkip:
+				tc.Skipped = &JUnitSkipped{Message: check.Message}
+			case CheckResultNameWarn:
+				tc.SystemOut = fmt.Sprintf("warn: %s\n%s", msg, check.Message)
+			}
+			if check.Documentation != "" {
+				tc.SystemOut = fmt.Sprintf("%s\ndocumentation: %s", tc.SystemOut, check.Documentation)
+			}
+			suite.add(tc)
+		}
+	}
+	return suite
+}
+
+func newJUnitPluginSuite(name string, p *ReportPlugin) *JUnitTestSuite {
+	suite := &JUnitTestSuite{Name: name, TestCases: []*JUnitTestCase{}}
+	for _, f := range p.FailedFiltered {
+		tc := &JUnitTestCase{
+			Name:      f.Name,
+			Classname: name,
+			Failure:   &JUnitFailure{Message: "test failed after filters"},
+		}
+		if test, ok := p.Tests[f.Name]; ok {
+			tc.Failure.Content = test.Failure
+			tc.SystemOut = test.SystemOut
+		}
+		suite.add(tc)
+	}
+	return suite
+}
+
+// SaveJUnit writes the JUnit XML of the report to the file.
+func (re *ReportData) SaveJUnit(path string) error {
+	data, err := xml.MarshalIndent(NewJUnit(re), "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode JUnit: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("unable to write JUnit file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+)
+
+func TestNewJUnit(t *testing.T) {
+	re := &ReportData{
+		Checks: &ReportChecks{
+			Fail:   []*SLOOutput{{ID: CheckID004, SLO: "OpenShift Conformance must pass", SLITarget: "pass", SLIActual: "fail", Message: "too many failures"}},
+			Warn:   []*SLOOutput{{ID: "OPCT-010A", SLO: "Pods must not be restarting"}},
+			Waived: []*SLOOutput{{ID: "OPCT-030", SLO: "Zones", Waiver: &CheckWaiver{ID: "OPCT-030", Approver: "jdoe", Expires: "2999-12-31", Justification: "single zone"}}},
+			Skip:   []*SLOOutput{{ID: "OPCT-011", SLO: "Skipped", Message: "not available"}},
+			Pass:   []*SLOOutput{{ID: CheckIdEmptyValue, SLO: "Cluster upgrade must not be Progressing"}},
+		},
+		Provider: &ReportResult{
+			Plugins: map[string]*ReportPlugin{
+				plugin.PluginNameOpenShiftConformance: {
+					Tests: map[string]*plugin.TestItem{
+						"[sig-network] test": {Name: "[sig-network] test", Failure: "timeout", SystemOut: "stdout"},
+					},
+					FailedFiltered: []*ReportTestFailure{{Name: "[sig-network] test"}},
+				},
+				plugin.PluginNameKubernetesConformance: {},
+			},
+		},
+	}
+
+	suites := NewJUnit(re)
+	assert.Equal(t, 6, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	assert.Equal(t, 2, suites.Skipped)
+	assert.Len(t, suites.Suites, 3)
+
+	checks := suites.Suites[0]
+	assert.Equal(t, JUnitSuiteChecks, checks.Name)
+	assert.Equal(t, "[OPCT-004] OpenShift Conformance must pass", checks.TestCases[0].Name)
+	assert.Equal(t, "too many failures", checks.TestCases[0].Failure.Content)
+	assert.Nil(t, checks.TestCases[1].Failure)
+	assert.Contains(t, checks.TestCases[1].SystemOut, "warn:")
+	assert.Equal(t, "waived by jdoe until 2999-12-31: single zone", checks.TestCases[2].Skipped.Message)
+	assert.Equal(t, "not available", checks.TestCases[3].Skipped.Message)
+	assert.Equal(t, "Cluster upgrade must not be Progressing", checks.TestCases[4].Name)
+
+	assert.Equal(t, plugin.PluginNameKubernetesConformance, suites.Suites[1].Name)
+	assert.Equal(t, 0, suites.Suites[1].Tests)
+	tc := suites.Suites[2].TestCases[0]
+	assert.Equal(t, "timeout", tc.Failure.Content)
+	assert.Equal(t, "stdout", tc.SystemOut)
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	assert.NoError(t, re.SaveJUnit(path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	decoded := &JUnitTestSuites{}
+	assert.NoError(t, xml.Unmarshal(data, decoded))
+	assert.Equal(t, suites.Tests, decoded.Tests)
+	assert.Len(t, decoded.Suites, 3)
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+)
+
+// Check profiles enable, disable or tune the default checks by cluster topology.
+const (
+	CheckProfileHA       = "ha"
+	CheckProfileCompact  = "compact"
+	CheckProfileSNO      = "sno"
+	CheckProfileExternal = "external"
+
+	topologyHighlyAvailable = "HighlyAvailable"
+	topologySingleReplica   = "SingleReplica"
+)
+
+// CheckProfiles are the supported check profiles.
+var CheckProfiles = []string{CheckProfileHA, CheckProfileCompact, CheckProfileSNO, CheckProfileExternal}
+
+//go:embed rules/profiles/*.yaml
+var checkProfilesFS embed.FS
+
+// ValidateCheckProfile returns an error when the profile is not supported.
+func ValidateCheckProfile(name string) error {
+	for _, p := range CheckProfiles {
+		if p == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid check profile %q, supported: %s", name, strings.Join(CheckProfiles, ", "))
+}
+
+// DetectCheckProfile selects the check profile from the infrastructure of the cluster:
+// sno : Topology or ControlPlaneTopology is SingleReplica
+// compact: HighlyAvailable with all the nodes in the control plane (schedulable masters)
+// external: platform type External
+// ha: default
+func DetectCheckProfile(rs *ReportResult) string {
+	if rs == nil || rs.Infra == nil {
+		return CheckProfileHA
+	}
+	if rs.Infra.Topology == topologySingleReplica || rs.Infra.ControlPlaneTopology == topologySingleReplica {
+		return CheckProfileSNO
+	}
+	if rs.Infra.ControlPlaneTopology == topologyHighlyAvailable && len(rs.Nodes) > 0 {
+		compact := true
+		for _, node := range rs.Nodes {
+			if !node.ControlPlane {
+				compact = false
+				break
+			}
+		}
+		if compact {
+			return CheckProfileCompact
+		}
+	}
+	if rs.Infra.PlatformType == "External" {
+		return CheckProfileExternal
+	}
+	return CheckProfileHA
+}
+
+// SetCheckProfile sets the check profile evaluated by Populate, overriding the
+// profile detected from the cluster topology.
+func (re *ReportData) SetCheckProfile(name string) {
+	re.checkProfile = name
+}
+
+func loadCheckProfile(name string) (*CheckRules, error) {
+	if err := ValidateCheckProfile(name); err != nil {
+		return nil, err
+	}
+	data, err := checkProfilesFS.ReadFile(fmt.Sprintf("rules/profiles/%s.yaml", name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read check profile %s: %w", name, err)
+	}
+	return parseCheckRules(data, name)
+}
+
+// WithProfile returns the rules applying the profile over the default rules,
+// then the rules file when the rules were loaded from a file.
+func (cr *CheckRules) WithProfile(name string) (*CheckRules, error) {
+	profile, err := loadCheckProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	rules := NewDefaultCheckRules()
+	rules.merge(profile)
+	if cr.overrides != nil {
+		rules.merge(cr.overrides)
+	}
+	rules.source = cr.source
+	rules.profile = name
+	rules.overrides = cr.overrides
+	if err := rules.compile(); err != nil {
+		return nil, fmt.Errorf("invalid check rules %s with profile %s: %w", cr.source, name, err)
+	}
+	return rules, nil
+}
+
+// Profile returns the check profile applied to the rules.
+func (cr *CheckRules) Profile() string {
+	return cr.profile
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+)
+
+func TestDetectCheckProfile(t *testing.T) {
+	controlPlane := []*summary.Node{{ControlPlane: true}, {ControlPlane: true}, {ControlPlane: true}}
+	workers := append([]*summary.Node{{}}, controlPlane...)
+	tests := []struct {
+		name  string
+		input *ReportResult
+		want  string
+	}{
+		{"no infra", &ReportResult{}, CheckProfileHA},
+		{"ha", &ReportResult{Infra: &ReportInfra{PlatformType: "AWS", Topology: "HighlyAvailable", ControlPlaneTopology: "HighlyAvailable"}, Nodes: workers}, CheckProfileHA},
+		{"sno", &ReportResult{Infra: &ReportInfra{PlatformType: "None", Topology: "SingleReplica", ControlPlaneTopology: "SingleReplica"}, Nodes: controlPlane[:1]}, CheckProfileSNO},
+		{"compact", &ReportResult{Infra: &ReportInfra{PlatformType: "External", Topology: "HighlyAvailable", ControlPlaneTopology: "HighlyAvailable"}, Nodes: controlPlane}, CheckProfileCompact},
+		{"external", &ReportResult{Infra: &ReportInfra{PlatformType: "External", Topology: "HighlyAvailable", ControlPlaneTopology: "HighlyAvailable"}, Nodes: workers}, CheckProfileExternal},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, DetectCheckProfile(tc.input))
+		})
+	}
+}
+
+func TestCheckRulesWithProfile(t *testing.T) {
+	results := func(rules *CheckRules, re *ReportData) map[string]CheckResult {
+		cs := NewCheckSummaryWithRules(re, rules)
+		assert.NoError(t, cs.Run())
+		assert.Equal(t, rules.Profile(), cs.GetProfile())
+		res := map[string]CheckResult{}
+		for _, check := range cs.Checks {
+			key := check.ID
+			if key == CheckIdEmptyValue {
+				key = check.Name
+			}
+			res[key] = check.Result
+		}
+		return res
+	}
+	for _, profile := range CheckProfiles {
+		t.Run(profile, func(t *testing.T) {
+			rules, err := NewDefaultCheckRules().WithProfile(profile)
+			assert.NoError(t, err)
+			assert.Equal(t, profile, rules.Profile())
+		})
+	}
+
+	t.Run("sno", func(t *testing.T) {
+		re := newRulesTestReport()
+		re.Provider.Infra = &ReportInfra{PlatformType: "None", Topology: "SingleReplica", ControlPlaneTopology: "SingleReplica"}
+		rules, err := NewDefaultCheckRules().WithProfile(CheckProfileSNO)
+		assert.NoError(t, err)
+		res := results(rules, re)
+		_, ok := res["OPCT-030"]
+		assert.False(t, ok)
+		_, ok = res["Infrastructure status must have Topology=HighlyAvailable"]
+		assert.False(t, ok)
+		assert.Equal(t, CheckResultNamePass, res["Infrastructure status must have Topology=SingleReplica"].Name)
+		assert.Equal(t, CheckResultNamePass, res["Infrastructure status must have ControlPlaneTopology=SingleReplica"].Name)
+	})
+
+	t.Run("external without zones", func(t *testing.T) {
+		re := newRulesTestReport()
+		re.Provider.Infra.PlatformType = "External"
+		for _, node := range re.Provider.Nodes {
+			node.Labels = nil
+		}
+		assert.Equal(t, CheckResultNameFail, results(NewDefaultCheckRules(), re)["OPCT-030"].Name)
+
+		rules, err := NewDefaultCheckRules().WithProfile(CheckProfileExternal)
+		assert.NoError(t, err)
+		assert.Equal(t, CheckResultNameSkip, results(rules, re)["OPCT-030"].Name)
+	})
+
+	t.Run("compact single zone", func(t *testing.T) {
+		re := newRulesTestReport()
+		for _, node := range re.Provider.Nodes {
+			node.Labels = map[string]string{"topology.kubernetes.io/zone": "a"}
+		}
+		rules, err := NewDefaultCheckRules().WithProfile(CheckProfileCompact)
+		assert.NoError(t, err)
+		assert.Equal(t, CheckResult{Name: CheckResultNameWarn, Target: "W:>1,P:>2", Actual: "Zones==1"}, results(rules, re)["OPCT-030"])
+	})
+
+	t.Run("external single zone", func(t *testing.T) {
+		re := newRulesTestReport()
+		re.Provider.Infra.PlatformType = "External"
+		for _, node := range re.Provider.Nodes {
+			node.Labels = map[string]string{"topology.kubernetes.io/zone": "a"}
+		}
+		rules, err := NewDefaultCheckRules().WithProfile(CheckProfileExternal)
+		assert.NoError(t, err)
+		assert.Equal(t, CheckResult{Name: CheckResultNameWarn, Target: "W:>1,P:>2", Actual: "Zones==1"}, results(rules, re)["OPCT-030"])
+	})
+
+	t.Run("rules file over profile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte("version: v1\nrules:\n- id: OPCT-030\n  disabled: true\n"), 0644))
+		loaded, err := LoadCheckRulesFromFile(path)
+		assert.NoError(t, err)
+		rules, err := loaded.WithProfile(CheckProfileCompact)
+		assert.NoError(t, err)
+		assert.Equal(t, path, rules.Source())
+		_, ok := results(rules, newRulesTestReport())["OPCT-030"]
+		assert.False(t, ok)
+	})
+
+	_, err := NewDefaultCheckRules().WithProfile("multi-cloud")
+	assert.Error(t, err)
+}
+
+func TestMergeConditions(t *testing.T) {
+	actual := "Zones==0"
+	base := []*CheckRuleCondition{
+		{ID: "nodes", When: "a", Result: CheckResultNameFail},
+		{ID: "zones", When: "b", Result: CheckResultNameFail},
+	}
+	merged := mergeConditions(base, []*CheckRuleCondition{
+		{ID: "zones", Result: CheckResultNameWarn},
+		{ID: "no-zones", Before: "zones", When: "c", Result: CheckResultNameSkip, Actual: &actual},
+		{ID: "last", When: "d", Result: CheckResultNamePass},
+	})
+	assert.Equal(t, []*CheckRuleCondition{
+		{ID: "nodes", When: "a", Result: CheckResultNameFail},
+		{ID: "no-zones", Before: "zones", When: "c", Result: CheckResultNameSkip, Actual: &actual},
+		{ID: "zones", When: "b", Result: CheckResultNameWarn},
+		{ID: "last", When: "d", Result: CheckResultNamePass},
+	}, merged)
+	assert.Equal(t, CheckResultNameFail, base[1].Result, "base conditions must not be changed")
+
+	// conditions without ID replace the conditions.
+	replace := []*CheckRuleCondition{{When: "e", Result: CheckResultNameFail}}
+	assert.Equal(t, replace, mergeConditions(base, replace))
+}
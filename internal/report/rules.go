@@ -0,0 +1,617 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/expr"
+)
+
+const (
+	// CheckRulesVersion is the supported version of the check rules file.
+	CheckRulesVersion = "v1"
+
+	// CheckRulesSourceDefault is the source of the embedded rules.
+	CheckRulesSourceDefault = "default"
+)
+
+//go:embed rules/checks.yaml
+var defaultCheckRules []byte
+
+// CheckRules is the versioned rule file defining the checks (SLOs) of the report.
+type CheckRules struct {
+	Version string       `yaml:"version"`
+	Rules   []*CheckRule `yaml:"rules"`
+
+	source  string
+	profile string
+
+	// overrides are the rules loaded from file, merged into the default rules.
+	overrides *CheckRules
+}
+
+// CheckRule is the declarative definition of a check. The result is the one of the
+// first condition evaluated to true, or the default result when none matches.
+//
+// Expressions (conditions and vars) are evaluated over the report data, see
+// the package expr for the syntax. The roots available are: provider, baseline,
+// summary, setup, thresholds, the vars of the rule, and the facts
+// runtimeFailedPlugins and controlPlaneZones.
+// Texts (name, target, actual and message) are templates interpolating ${expressions},
+// name and target can reference only the thresholds.
+type CheckRule struct {
+	// ID is the check ID, used to mount the documentation. Use '--' for checks
+	// without documentation.
+	ID            string `yaml:"id,omitempty"`
+	Name          string `yaml:"name,omitempty"`
+	Description   string `yaml:"description,omitempty"`
+	Documentation string `yaml:"documentation,omitempty"`
+
+	// Priority is the order of the check in the report. 0 is higher.
+	Priority *uint64 `yaml:"priority,omitempty"`
+
+	// Disabled removes the check, used to override the default rules.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	Thresholds map[string]float64 `yaml:"thresholds,omitempty"`
+
+	// Vars are expressions evaluated on demand, a var can reference the vars defined before.
+	Vars yaml.MapSlice `yaml:"vars,omitempty"`
+
+	Target     string                `yaml:"target,omitempty"`
+	Actual     string                `yaml:"actual,omitempty"`
+	Message    string                `yaml:"message,omitempty"`
+	Conditions []*CheckRuleCondition `yaml:"conditions,omitempty"`
+
+	// Default is the result when no condition matches. Default: pass.
+	Default CheckResultName `yaml:"default,omitempty"`
+
+	vars    []*checkRuleVar
+	name    *expr.Template
+	target  *expr.Template
+	actual  *expr.Template
+	message *expr.Template
+}
+
+// CheckRuleCondition sets the result of the check when the expression is true.
+// Actual and Message override the texts of the rule.
+//
+// ID identifies the condition in the rule. When all the conditions of an overriding
+// rule have an ID, the conditions are merged by ID: the fields set override the
+// condition with the same ID, and new conditions are inserted before the condition
+// referenced by Before (default: at the end).
+type CheckRuleCondition struct {
+	ID      string          `yaml:"id,omitempty"`
+	Before  string          `yaml:"before,omitempty"`
+	When    string          `yaml:"when,omitempty"`
+	Result  CheckResultName `yaml:"result,omitempty"`
+	Actual  *string         `yaml:"actual,omitempty"`
+	Message *string         `yaml:"message,omitempty"`
+
+	when    *expr.Expression
+	actual  *expr.Template
+	message *expr.Template
+}
+
+type checkRuleVar struct {
+	name string
+	expr *expr.Expression
+}
+
+// checkRootNames are the roots of the expressions, which can't be used as var names.
+var checkRootNames = []string{"provider", "baseline", "summary", "setup", "thresholds", "runtimeFailedPlugins", "controlPlaneZones"}
+
+// NewDefaultCheckRules returns the embedded rules. The embedded rules are
+// validated by the unit tests, an invalid file panics.
+func NewDefaultCheckRules() *CheckRules {
+	rules, err := parseCheckRules(defaultCheckRules, CheckRulesSourceDefault)
+	if err != nil {
+		panic(err)
+	}
+	if err := rules.compile(); err != nil {
+		panic(fmt.Errorf("invalid default check rules: %w", err))
+	}
+	return rules
+}
+
+// LoadCheckRulesFromFile reads the rules file, merging it into the default rules:
+// rules matching an ID (or the name, when the ID is '--') override the default
+// rule fields and thresholds, other rules are appended.
+func LoadCheckRulesFromFile(path string) (*CheckRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read check rules file: %w", err)
+	}
+	override, err := parseCheckRules(data, path)
+	if err != nil {
+		return nil, err
+	}
+	rules := NewDefaultCheckRules()
+	rules.merge(override)
+	rules.source = path
+	rules.overrides = override
+	if err := rules.compile(); err != nil {
+		return nil, fmt.Errorf("invalid check rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// SetCheckRules sets the rules of the checks evaluated by Populate.
+func (re *ReportData) SetCheckRules(rules *CheckRules) {
+	re.checkRules = rules
+}
+
+func parseCheckRules(data []byte, source string) (*CheckRules, error) {
+	rules := &CheckRules{source: source}
+	if err := yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, fmt.Errorf("unable to parse check rules file %s: %w", source, err)
+	}
+	if rules.Version != CheckRulesVersion {
+		return nil, fmt.Errorf("unsupported check rules version %q in %s, want %q", rules.Version, source, CheckRulesVersion)
+	}
+	return rules, nil
+}
+
+// Source returns the source of the rules: default, or the file path.
+func (cr *CheckRules) Source() string {
+	return cr.source
+}
+
+// IDs returns the IDs of the enabled rules, ignoring the rules without ID ('--').
+func (cr *CheckRules) IDs() map[string]struct{} {
+	ids := make(map[string]struct{}, len(cr.Rules))
+	for _, r := range cr.Rules {
+		if r.Disabled || r.ID == "" || r.ID == CheckIdEmptyValue {
+			continue
+		}
+		ids[r.ID] = struct{}{}
+	}
+	return ids
+}
+
+// find returns the rule by ID, or by name when the ID is empty.
+func (cr *CheckRules) find(rule *CheckRule) *CheckRule {
+	for _, r := range cr.Rules {
+		if rule.ID != "" && rule.ID != CheckIdEmptyValue && r.ID == rule.ID {
+			return r
+		}
+		if (rule.ID == "" || rule.ID == CheckIdEmptyValue) && rule.Name != "" && r.Name == rule.Name {
+			return r
+		}
+	}
+	return nil
+}
+
+func (cr *CheckRules) merge(override *CheckRules) {
+	for _, rule := range override.Rules {
+		r := cr.find(rule)
+		if r == nil {
+			cr.Rules = append(cr.Rules, rule)
+			continue
+		}
+		if rule.Disabled {
+			log.Debugf("check rules: disabling check %s %q", r.ID, r.Name)
+			r.Disabled = true
+			continue
+		}
+		r.override(rule)
+	}
+	enabled := []*CheckRule{}
+	for _, r := range cr.Rules {
+		if !r.Disabled {
+			enabled = append(enabled, r)
+		}
+	}
+	cr.Rules = enabled
+	sort.SliceStable(cr.Rules, func(i, j int) bool {
+		return cr.Rules[i].priority() < cr.Rules[j].priority()
+	})
+}
+
+// compile validates and compiles the expressions of the rules.
+func (cr *CheckRules) compile() error {
+	ids := map[string]struct{}{}
+	names := map[string]struct{}{}
+	for idx, r := range cr.Rules {
+		if r.ID == "" || r.Name == "" {
+			return fmt.Errorf("rule #%d: 'id' and 'name' must be set", idx)
+		}
+		if _, ok := ids[r.ID]; ok && r.ID != CheckIdEmptyValue {
+			return fmt.Errorf("rule #%d: duplicated id %s", idx, r.ID)
+		}
+		ids[r.ID] = struct{}{}
+		if _, ok := names[r.Name]; ok {
+			return fmt.Errorf("rule #%d: duplicated name %q", idx, r.Name)
+		}
+		names[r.Name] = struct{}{}
+		if err := r.compile(); err != nil {
+			return fmt.Errorf("rule %s %q: %w", r.ID, r.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *CheckRule) priority() uint64 {
+	if r.Priority == nil {
+		return 0
+	}
+	return *r.Priority
+}
+
+// override sets the fields defined in the rule o.
+func (r *CheckRule) override(o *CheckRule) {
+	if o.Name != "" {
+		r.Name = o.Name
+	}
+	if o.Description != "" {
+		r.Description = o.Description
+	}
+	if o.Documentation != "" {
+		r.Documentation = o.Documentation
+	}
+	if o.Priority != nil {
+		r.Priority = o.Priority
+	}
+	if len(o.Thresholds) > 0 && r.Thresholds == nil {
+		r.Thresholds = map[string]float64{}
+	}
+	for k, v := range o.Thresholds {
+		r.Thresholds[k] = v
+	}
+	for _, ov := range o.Vars {
+		found := false
+		for idx := range r.Vars {
+			if r.Vars[idx].Key == ov.Key {
+				r.Vars[idx].Value = ov.Value
+				found = true
+			}
+		}
+		if !found {
+			r.Vars = append(r.Vars, ov)
+		}
+	}
+	if o.Target != "" {
+		r.Target = o.Target
+	}
+	if o.Actual != "" {
+		r.Actual = o.Actual
+	}
+	if o.Message != "" {
+		r.Message = o.Message
+	}
+	if len(o.Conditions) > 0 {
+		r.Conditions = mergeConditions(r.Conditions, o.Conditions)
+	}
+	if o.Default != "" {
+		r.Default = o.Default
+	}
+}
+
+// mergeConditions merges the conditions by ID when all the overriding conditions
+// have an ID, otherwise the overriding conditions replace the conditions.
+func mergeConditions(conditions, overrides []*CheckRuleCondition) []*CheckRuleCondition {
+	for _, o := range overrides {
+		if o.ID == "" {
+			return overrides
+		}
+	}
+	merged := make([]*CheckRuleCondition, 0, len(conditions)+len(overrides))
+	for _, c := range conditions {
+		c := *c
+		merged = append(merged, &c)
+	}
+	indexOf := func(id string) int {
+		for idx, c := range merged {
+			if id != "" && c.ID == id {
+				return idx
+			}
+		}
+		return -1
+	}
+	for _, o := range overrides {
+		if idx := indexOf(o.ID); idx >= 0 {
+			merged[idx].override(o)
+			continue
+		}
+		c := *o
+		idx := indexOf(o.Before)
+		if idx < 0 {
+			merged = append(merged, &c)
+			continue
+		}
+		merged = append(merged[:idx], append([]*CheckRuleCondition{&c}, merged[idx:]...)...)
+	}
+	return merged
+}
+
+// override sets the fields defined in the condition o.
+func (c *CheckRuleCondition) override(o *CheckRuleCondition) {
+	if o.When != "" {
+		c.When = o.When
+	}
+	if o.Result != "" {
+		c.Result = o.Result
+	}
+	if o.Actual != nil {
+		c.Actual = o.Actual
+	}
+	if o.Message != nil {
+		c.Message = o.Message
+	}
+}
+
+func validCheckResult(res CheckResultName) bool {
+	switch res {
+	case CheckResultNamePass, CheckResultNameFail, CheckResultNameWarn, CheckResultNameSkip:
+		return true
+	}
+	return false
+}
+
+func (r *CheckRule) compile() error {
+	var err error
+	if r.Default == "" {
+		r.Default = CheckResultNamePass
+	}
+	if !validCheckResult(r.Default) {
+		return fmt.Errorf("invalid default result %q", r.Default)
+	}
+
+	r.vars = []*checkRuleVar{}
+	defined := map[string]struct{}{}
+	for _, root := range checkRootNames {
+		defined[root] = struct{}{}
+	}
+	for _, v := range r.Vars {
+		name, ok := v.Key.(string)
+		if !ok {
+			return fmt.Errorf("invalid var name %v", v.Key)
+		}
+		if _, ok := defined[name]; ok {
+			return fmt.Errorf("var %s: name already defined", name)
+		}
+		src, ok := v.Value.(string)
+		if !ok {
+			return fmt.Errorf("var %s: expression must be a string", name)
+		}
+		e, err := expr.Compile(src)
+		if err != nil {
+			return fmt.Errorf("var %s: %w", name, err)
+		}
+		// vars can reference only the vars defined before.
+		for _, id := range e.Identifiers() {
+			if _, ok := defined[id]; !ok && r.isVar(id) {
+				return fmt.Errorf("var %s: references the var %s defined later", name, id)
+			}
+		}
+		defined[name] = struct{}{}
+		r.vars = append(r.vars, &checkRuleVar{name: name, expr: e})
+	}
+
+	if r.name, err = expr.CompileTemplate(r.Name); err != nil {
+		return fmt.Errorf("name: %w", err)
+	}
+	if r.target, err = expr.CompileTemplate(r.Target); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	if r.actual, err = expr.CompileTemplate(r.Actual); err != nil {
+		return fmt.Errorf("actual: %w", err)
+	}
+	if r.message, err = expr.CompileTemplate(r.Message); err != nil {
+		return fmt.Errorf("message: %w", err)
+	}
+	// name and target are static, rendered with the thresholds.
+	if _, err := r.name.Eval(r.staticEnv()); err != nil {
+		return fmt.Errorf("name: %w", err)
+	}
+	if _, err := r.target.Eval(r.staticEnv()); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+
+	if err := undefinedIdentifier(defined, r.actual.Identifiers()); err != nil {
+		return fmt.Errorf("actual: %w", err)
+	}
+	if err := undefinedIdentifier(defined, r.message.Identifiers()); err != nil {
+		return fmt.Errorf("message: %w", err)
+	}
+
+	conditionIDs := map[string]struct{}{}
+	for _, c := range r.Conditions {
+		if c.ID == "" {
+			continue
+		}
+		if _, ok := conditionIDs[c.ID]; ok {
+			return fmt.Errorf("duplicated condition id %s", c.ID)
+		}
+		conditionIDs[c.ID] = struct{}{}
+	}
+	for idx, c := range r.Conditions {
+		if c.When == "" {
+			return fmt.Errorf("condition #%d: 'when' must be set", idx)
+		}
+		if _, ok := conditionIDs[c.Before]; c.Before != "" && !ok {
+			return fmt.Errorf("condition #%d: unknown condition %s referenced by 'before'", idx, c.Before)
+		}
+		if !validCheckResult(c.Result) {
+			return fmt.Errorf("condition #%d: invalid result %q", idx, c.Result)
+		}
+		if c.when, err = expr.Compile(c.When); err != nil {
+			return fmt.Errorf("condition #%d: %w", idx, err)
+		}
+		if err := undefinedIdentifier(defined, c.when.Identifiers()); err != nil {
+			return fmt.Errorf("condition #%d: %w", idx, err)
+		}
+		if c.Actual != nil {
+			if c.actual, err = expr.CompileTemplate(*c.Actual); err != nil {
+				return fmt.Errorf("condition #%d: actual: %w", idx, err)
+			}
+			if err := undefinedIdentifier(defined, c.actual.Identifiers()); err != nil {
+				return fmt.Errorf("condition #%d: actual: %w", idx, err)
+			}
+		}
+		if c.Message != nil {
+			if c.message, err = expr.CompileTemplate(*c.Message); err != nil {
+				return fmt.Errorf("condition #%d: message: %w", idx, err)
+			}
+			if err := undefinedIdentifier(defined, c.message.Identifiers()); err != nil {
+				return fmt.Errorf("condition #%d: message: %w", idx, err)
+			}
+		}
+	}
+	return nil
+}
+
+// undefinedIdentifier returns an error when one of the identifiers is not a root,
+// or a var, defined in the rule.
+func undefinedIdentifier(defined map[string]struct{}, ids []string) error {
+	for _, id := range ids {
+		if _, ok := defined[id]; !ok {
+			return fmt.Errorf("undefined identifier %s", id)
+		}
+	}
+	return nil
+}
+
+func (r *CheckRule) isVar(name string) bool {
+	for _, v := range r.Vars {
+		if v.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CheckRule) staticEnv() expr.Env {
+	return expr.Env{"thresholds": r.Thresholds}
+}
+
+// render evaluates the static template, falling back to the source on errors.
+func (r *CheckRule) render(tpl *expr.Template) string {
+	s, err := tpl.Eval(r.staticEnv())
+	if err != nil {
+		return tpl.String()
+	}
+	return s
+}
+
+// env returns the environment to evaluate the rule over the report data.
+func (r *CheckRule) env(re *ReportData) expr.Env {
+	env := expr.Env{
+		"provider":   re.Provider,
+		"baseline":   re.Baseline,
+		"summary":    re.Summary,
+		"setup":      re.Setup,
+		"thresholds": r.Thresholds,
+		"runtimeFailedPlugins": lazyOnce(func() (interface{}, error) {
+			return runtimeFailedPlugins(re), nil
+		}),
+		"controlPlaneZones": lazyOnce(func() (interface{}, error) {
+			return controlPlaneZones(re), nil
+		}),
+	}
+	for _, v := range r.vars {
+		v := v
+		env[v.name] = lazyOnce(func() (interface{}, error) {
+			return v.expr.Eval(env)
+		})
+	}
+	return env
+}
+
+// Evaluate evaluates the rule over the report data.
+func (r *CheckRule) Evaluate(re *ReportData) CheckResult {
+	env := r.env(re)
+	res := CheckResult{Name: r.Default, Target: r.render(r.target)}
+	actual, message := r.actual, r.message
+	for idx, c := range r.Conditions {
+		match, err := c.when.EvalBool(env)
+		if err != nil {
+			log.Warnf("Check %s %q: condition #%d: unable to evaluate: %v", r.ID, r.Name, idx, err)
+			res.Name = CheckResultNameFail
+			res.Actual = "N/A"
+			res.Message = fmt.Sprintf("unable to evaluate the condition #%d (%s): %v", idx, c.When, err)
+			return res
+		}
+		if !match {
+			continue
+		}
+		res.Name = c.Result
+		if c.actual != nil {
+			actual = c.actual
+		}
+		if c.message != nil {
+			message = c.message
+		}
+		break
+	}
+	var err error
+	if res.Actual, err = actual.Eval(env); err != nil {
+		log.Debugf("Check %s %q: actual: unable to evaluate: %v", r.ID, r.Name, err)
+		res.Actual = "N/A"
+	}
+	if res.Message, err = message.Eval(env); err != nil {
+		log.Debugf("Check %s %q: message: unable to evaluate: %v", r.ID, r.Name, err)
+	}
+	return res
+}
+
+// lazyOnce memoizes the value, evaluating it once by rule evaluation.
+func lazyOnce(fn expr.Lazy) expr.Lazy {
+	var done bool
+	var value interface{}
+	var err error
+	return func() (interface{}, error) {
+		if !done {
+			value, err = fn()
+			done = true
+		}
+		return value, err
+	}
+}
+
+// runtimeFailedPlugins returns the ID prefix of the plugins which Total and Failed
+// counters are equals, indicating execution failure.
+func runtimeFailedPlugins(re *ReportData) []string {
+	failed := []string{}
+	if re.Provider == nil {
+		return failed
+	}
+	for _, name := range []string{
+		plugin.PluginNameKubernetesConformance,
+		plugin.PluginNameOpenShiftConformance,
+		plugin.PluginNameArtifactsCollector,
+	} {
+		p, ok := re.Provider.Plugins[name]
+		if !ok || p.Stat == nil {
+			continue
+		}
+		if p.Stat.Total == p.Stat.Failed {
+			failed = append(failed, strings.Split(name, "-")[0])
+		}
+	}
+	return failed
+}
+
+// controlPlaneZones returns the count of zones of the control plane nodes.
+func controlPlaneZones(re *ReportData) int {
+	if re.Provider == nil {
+		return 0
+	}
+	zones := map[string]struct{}{}
+	for _, node := range re.Provider.Nodes {
+		if !node.ControlPlane {
+			continue
+		}
+		if zone, ok := node.Labels["topology.kubernetes.io/zone"]; ok {
+			zones[zone] = struct{}{}
+		}
+	}
+	return len(zones)
+}
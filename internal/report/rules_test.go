@@ -0,0 +1,187 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/archive"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+)
+
+func newRulesTestReport() *ReportData {
+	errorCounters := archive.ErrorCounter{"total": 200}
+	return &ReportData{
+		Provider: &ReportResult{
+			Version: &ReportVersion{OpenShift: &summary.SummaryClusterVersionOutput{
+				CondAvailable: "True", CondFailing: "False", CondProgressing: "False", CondReleaseAccepted: "True",
+			}},
+			Infra:         &ReportInfra{PlatformType: "AWS", Topology: "HighlyAvailable", ControlPlaneTopology: "HighlyAvailable"},
+			ClusterHealth: &ReportClusterHealth{NodeHealthPerc: 100, PodHealthPerc: 97.1234},
+			Plugins: map[string]*ReportPlugin{
+				plugin.PluginNameKubernetesConformance: {Stat: &ReportPluginStat{Total: 400, Failed: 400}},
+				plugin.PluginNameOpenShiftConformance: {
+					Stat:    &ReportPluginStat{Total: 3500, Failed: 40, FilterFailures: 3},
+					Filters: []*ReportPluginFilter{{ID: plugin.FilterNameFlaky, Failures: 10}},
+				},
+				plugin.PluginNameArtifactsCollector: {Stat: &ReportPluginStat{Total: 1, Status: "passed"}},
+			},
+			ErrorCounters: &errorCounters,
+			MustGatherInfo: &mustgather.MustGather{
+				ErrorCounters: archive.ErrorCounter{"total": 50000},
+				ErrorEtcdLogs: &mustgather.ErrorEtcdLogs{FilterRequestSlowAll: map[string]*mustgather.BucketFilterStat{
+					"all": {StatMean: "234.5 (ms)", StatMax: ""},
+				}},
+			},
+			Nodes: []*summary.Node{
+				{ControlPlane: true, Labels: map[string]string{"topology.kubernetes.io/zone": "a"}},
+				{ControlPlane: true, Labels: map[string]string{"topology.kubernetes.io/zone": "b"}},
+				{ControlPlane: true, Labels: map[string]string{"topology.kubernetes.io/zone": "b"}},
+			},
+		},
+	}
+}
+
+func TestDefaultCheckRules(t *testing.T) {
+	cs := NewCheckSummary(newRulesTestReport())
+	assert.NoError(t, cs.Run())
+	assert.Equal(t, CheckRulesSourceDefault, cs.GetRules())
+
+	results := map[string]CheckResult{}
+	for _, check := range cs.Checks {
+		if check.ID != CheckIdEmptyValue {
+			results[check.ID] = check.Result
+		}
+	}
+	tests := []struct {
+		id   string
+		want CheckResult
+	}{
+		{"OPCT-020", CheckResult{Name: CheckResultNamePass, Target: "100%", Actual: "100.000%"}},
+		{"OPCT-021", CheckResult{Name: CheckResultNameFail, Target: ">=98%", Actual: "97.123"}},
+		{CheckID001, CheckResult{Name: CheckResultNameFail, Target: "Priority==0|Total!=Failed", Actual: "Total==Failed", Message: "Potential Runtime Failure. Check the Plugin logs."}},
+		{CheckID004, CheckResult{Name: CheckResultNamePass, Target: "Pass>=98.5%(Fail>1.5%)", Actual: "Fail==1.14%(40)"}},
+		{CheckID005, CheckResult{Name: CheckResultNamePass, Target: "W<=0.50%,F>0.50%", Actual: "Fail==0.29%(10)"}},
+		{"OPCT-005B", CheckResult{Name: CheckResultNameWarn, Target: "Pass==100%(W<=0.50%,F>0.50%)", Actual: "Fail==0.09%(3)"}},
+		{"OPCT-011", CheckResult{Name: CheckResultNameWarn, Target: "Pass<=150(W>150,F>300)", Actual: "200"}},
+		{"OPCT-010", CheckResult{Name: CheckResultNameWarn, Target: "W:<=30k,F:>100k", Actual: "50000"}},
+		{"OPCT-003", CheckResult{Name: CheckResultNamePass, Target: "passed", Actual: "passed"}},
+		{"OPCT-002", CheckResult{Name: CheckResultNameFail, Target: "passed"}},
+		{"OPCT-010A", CheckResult{Name: CheckResultNamePass, Target: "<=500.00 ms", Actual: "234.500"}},
+		{"OPCT-010B", CheckResult{Name: CheckResultNameFail, Target: "<=1000.00 ms", Actual: "ERR !max"}},
+		{CheckID022, CheckResult{Name: CheckResultNameFail, Target: "passed", Actual: "Failed[10]"}},
+		{CheckID023A, CheckResult{Name: CheckResultNamePass, Target: "F:<300", Actual: "Total==400"}},
+		{CheckID023B, CheckResult{Name: CheckResultNamePass, Target: "F:<3000", Actual: "Total==3500"}},
+		{"OPCT-030", CheckResult{Name: CheckResultNamePass, Target: "W:>1,P:>2", Actual: "Zones==2"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			assert.Equal(t, tc.want, results[tc.id])
+		})
+	}
+
+	// checks without ID must not be documented
+	for _, check := range cs.Checks {
+		if check.ID == CheckIdEmptyValue {
+			assert.Equal(t, CheckResultNamePass, check.Result.Name, check.Name)
+			assert.Empty(t, check.Documentation)
+		}
+	}
+}
+
+func TestLoadCheckRulesFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("override and extend", func(t *testing.T) {
+		path := write("rules.yaml", `version: v1
+rules:
+- id: OPCT-021
+  thresholds:
+    minPodHealthPerc: 95
+- id: OPCT-011
+  disabled: true
+- id: "--"
+  name: Cluster upgrade must not be Progressing
+  disabled: true
+- id: OPCT-900
+  name: Cluster must have ${thresholds.nodes} nodes
+  documentation: https://example.com/opct-900
+  priority: 1
+  thresholds:
+    nodes: 3
+  vars:
+    count: len(provider.nodes)
+  target: ">=${thresholds.nodes}"
+  actual: ${count}
+  conditions:
+  - when: count < thresholds.nodes
+    result: fail
+`)
+		rules, err := LoadCheckRulesFromFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, path, rules.Source())
+
+		cs := NewCheckSummaryWithRules(newRulesTestReport(), rules)
+		assert.NoError(t, cs.Run())
+		assert.Equal(t, len(NewDefaultCheckRules().Rules)-1, len(cs.Checks))
+
+		last := cs.Checks[len(cs.Checks)-1]
+		assert.Equal(t, "OPCT-900", last.ID)
+		assert.Equal(t, "Cluster must have 3 nodes", last.Name)
+		assert.Equal(t, "https://example.com/opct-900", last.Documentation)
+		assert.Equal(t, CheckResult{Name: CheckResultNamePass, Target: ">=3", Actual: "3"}, last.Result)
+
+		for _, check := range cs.Checks {
+			assert.NotEqual(t, "OPCT-011", check.ID)
+			assert.NotEqual(t, "Cluster upgrade must not be Progressing", check.Name)
+			if check.ID == "OPCT-021" {
+				assert.Equal(t, "Pods Healthy must report higher than 95%", check.Name)
+				assert.Equal(t, CheckResult{Name: CheckResultNamePass, Target: ">=95%", Actual: "97.123"}, check.Result)
+			}
+		}
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		rules, err := LoadCheckRulesFromFile(write("runtime.yaml", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  conditions:\n  - when: provider.nodes + 1 > 0\n    result: pass\n"))
+		assert.NoError(t, err)
+		cs := NewCheckSummaryWithRules(newRulesTestReport(), rules)
+		assert.NoError(t, cs.Run())
+		last := cs.Checks[len(cs.Checks)-1]
+		assert.Equal(t, "OPCT-901", last.ID)
+		assert.Equal(t, CheckResultNameFail, last.Result.Name)
+		assert.Equal(t, "N/A", last.Result.Actual)
+		assert.Contains(t, last.Result.Message, "unable to evaluate the condition #0 (provider.nodes + 1 > 0)")
+	})
+
+	invalid := []struct {
+		name    string
+		content string
+	}{
+		{"unsupported version", "version: v2\nrules: []\n"},
+		{"unknown field", "version: v1\nrules:\n- id: OPCT-021\n  threshold: {}\n"},
+		{"missing name", "version: v1\nrules:\n- id: OPCT-901\n"},
+		{"invalid result", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  conditions:\n  - when: 'true'\n    result: maybe\n"},
+		{"invalid expression", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  conditions:\n  - when: 'len(provider.nodes'\n    result: fail\n"},
+		{"var defined later", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  vars:\n    a: b + 1\n    b: '1'\n"},
+		{"unknown threshold", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  target: ${thresholds.max}\n"},
+		{"unknown condition override", "version: v1\nrules:\n- id: OPCT-030\n  conditions:\n  - id: zone\n    result: warn\n"},
+		{"unknown condition before", "version: v1\nrules:\n- id: OPCT-030\n  conditions:\n  - id: new\n    before: zone\n    when: 'true'\n    result: warn\n"},
+		{"undefined identifier", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  conditions:\n  - when: provder.nodes == null\n    result: fail\n"},
+		{"undefined identifier in actual", "version: v1\nrules:\n- id: OPCT-901\n  name: x\n  actual: ${count}\n"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadCheckRulesFromFile(write("invalid.yaml", tc.content))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	table "github.com/jedib0t/go-pretty/v6/table"
+	log "github.com/sirupsen/logrus"
+
+	vfs "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/assets"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+)
+
+// Output formats of the trend report.
+const (
+	TrendFormatTable = "table"
+	TrendFormatJSON  = "json"
+	TrendFormatHTML  = "html"
+
+	// TrendTemplateHTML is the template of the HTML output, in ReportTemplateBasePath.
+	TrendTemplateHTML = "trend.html"
+)
+
+// TrendFormats are the supported output formats of the trend report.
+var TrendFormats = []string{TrendFormatTable, TrendFormatJSON, TrendFormatHTML}
+
+// Test results of a run in the trend report, matching the check results.
+const (
+	TrendTestFailed = string(CheckResultNameFail)
+	TrendTestPassed = string(CheckResultNamePass)
+)
+
+// TrendPlugin is the plugin result of a run.
+type TrendPlugin struct {
+	Name           string `json:"name"`
+	Total          int64  `json:"total"`
+	Passed         int64  `json:"passed"`
+	Failed         int64  `json:"failed"`
+	FailedFiltered int64  `json:"failedFiltered"`
+
+	// PassRatio is the percentage of passed tests.
+	PassRatio float64 `json:"passRatio"`
+}
+
+// TrendRun is the summary of a processed result in the trend report.
+type TrendRun struct {
+	Name             string         `json:"name"`
+	ExecutionDate    string         `json:"executionDate,omitempty"`
+	OpenShiftVersion string         `json:"openshiftVersion,omitempty"`
+	Plugins          []*TrendPlugin `json:"plugins"`
+
+	// Check results counters.
+	ChecksPass   int `json:"checksPass"`
+	ChecksFail   int `json:"checksFail"`
+	ChecksWarn   int `json:"checksWarn"`
+	ChecksSkip   int `json:"checksSkip"`
+	ChecksWaived int `json:"checksWaived"`
+
+	// EtcdP99 is the p99 latency (ms) of etcd slow requests, nil when not available.
+	EtcdP99 *float64 `json:"etcdP99,omitempty"`
+}
+
+// GetPlugin returns the plugin result of the run by name, or nil when not found.
+func (tr *TrendRun) GetPlugin(name string) *TrendPlugin {
+	for _, p := range tr.Plugins {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// TrendCheck is the result of a check on each run, CheckIdEmptyValue when not found.
+type TrendCheck struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Results []string `json:"results"`
+}
+
+// TrendCounter is an error counter on each run.
+type TrendCounter struct {
+	Source string `json:"source"`
+	Name   string `json:"name"`
+	Values []int  `json:"values"`
+}
+
+// TrendTest is a test failing (after filters) in some runs but not others, pointing
+// to environment instability. Results are TrendTestFailed or TrendTestPassed on each
+// run, or CheckIdEmptyValue when the plugin has not run.
+type TrendTest struct {
+	Plugin   string   `json:"plugin"`
+	Name     string   `json:"name"`
+	Failures int      `json:"failures"`
+	Runs     int      `json:"runs"`
+	Results  []string `json:"results"`
+}
+
+// ReportTrend is the time-ordered view of multiple processed results.
+type ReportTrend struct {
+	Runs          []*TrendRun     `json:"runs"`
+	Checks        []*TrendCheck   `json:"checks"`
+	ErrorCounters []*TrendCounter `json:"errorCounters"`
+	Intermittent  []*TrendTest    `json:"intermittent"`
+}
+
+// sortTrendReports sorts the reports by execution date, keeping the order of the
+// arguments when the date is not available in all reports.
+func sortTrendReports(names []string, reports []*ReportData) {
+	dates := make([]time.Time, len(reports))
+	for i, re := range reports {
+		if re.Setup == nil || re.Setup.API == nil {
+			log.Warnf("trend: execution date not found in %s, runs are ordered by arguments", names[i])
+			return
+		}
+		date, err := time.Parse(time.RFC3339, re.Setup.API.ExecutionDate)
+		if err != nil {
+			log.Warnf("trend: unable to parse execution date of %s, runs are ordered by arguments: %v", names[i], err)
+			return
+		}
+		dates[i] = date
+	}
+	idx := make([]int, len(reports))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return dates[idx[i]].Before(dates[idx[j]]) })
+	sortedNames, sortedReports := make([]string, len(names)), make([]*ReportData, len(reports))
+	for i, j := range idx {
+		sortedNames[i], sortedReports[i] = names[j], reports[j]
+	}
+	copy(names, sortedNames)
+	copy(reports, sortedReports)
+}
+
+// newTrendRun builds the summary of the run from the report.
+func newTrendRun(name string, re *ReportData) *TrendRun {
+	run := &TrendRun{Name: name, Plugins: []*TrendPlugin{}}
+	if re.Setup != nil && re.Setup.API != nil {
+		run.ExecutionDate = re.Setup.API.ExecutionDate
+		run.OpenShiftVersion = re.Setup.API.OpenShiftVersion
+	}
+	for _, pluginName := range plugin.ConformancePlugins {
+		p := getPlugin(re, pluginName)
+		if p.Stat.Total == 0 {
+			continue
+		}
+		run.Plugins = append(run.Plugins, &TrendPlugin{
+			Name:           pluginName,
+			Total:          p.Stat.Total,
+			Passed:         p.Stat.Passed,
+			Failed:         p.Stat.Failed,
+			FailedFiltered: int64(len(p.FailedFiltered)),
+			PassRatio:      float64(100*p.Stat.Passed) / float64(p.Stat.Total),
+		})
+	}
+	if re.Checks != nil {
+		run.ChecksPass, run.ChecksFail, run.ChecksWarn = len(re.Checks.Pass), len(re.Checks.Fail), len(re.Checks.Warn)
+		run.ChecksSkip, run.ChecksWaived = len(re.Checks.Skip), len(re.Checks.Waived)
+	}
+	if stat := getEtcdSlowRequests(re); stat != nil {
+		if p99, ok := parseLatency(stat.StatPerc99); ok {
+			run.EtcdP99 = &p99
+		}
+	}
+	return run
+}
+
+// NewReportTrend builds the trend of the reports, ordered by execution date.
+func NewReportTrend(names []string, reports []*ReportData) (*ReportTrend, error) {
+	if len(names) != len(reports) {
+		return nil, fmt.Errorf("trend: got %d names for %d reports", len(names), len(reports))
+	}
+	names, reports = append([]string{}, names...), append([]*ReportData{}, reports...)
+	sortTrendReports(names, reports)
+
+	trend := &ReportTrend{
+		Runs:          []*TrendRun{},
+		Checks:        []*TrendCheck{},
+		ErrorCounters: []*TrendCounter{},
+		Intermittent:  []*TrendTest{},
+	}
+	checks := map[string]*TrendCheck{}
+	counters := map[string]*TrendCounter{}
+	tests := map[string]*TrendTest{}
+	for i, re := range reports {
+		run := newTrendRun(names[i], re)
+		trend.Runs = append(trend.Runs, run)
+
+		for key, check := range getCheckResults(re) {
+			if _, ok := checks[key]; !ok {
+				checks[key] = &TrendCheck{ID: key, Name: check.SLO, Results: make([]string, len(reports))}
+			}
+			checks[key].Results[i] = check.SLOResult
+		}
+
+		for source, errorCounters := range getErrorCounters(re) {
+			for name, value := range errorCounters {
+				key := source + "/" + name
+				if _, ok := counters[key]; !ok {
+					counters[key] = &TrendCounter{Source: source, Name: name, Values: make([]int, len(reports))}
+				}
+				counters[key].Values[i] = value
+			}
+		}
+
+		for _, pluginName := range plugin.ConformancePlugins {
+			for _, name := range getPlugin(re, pluginName).GetFailedFilteredNames() {
+				key := pluginName + "/" + name
+				if _, ok := tests[key]; !ok {
+					tests[key] = &TrendTest{Plugin: pluginName, Name: name, Results: make([]string, len(reports))}
+				}
+				tests[key].Results[i] = TrendTestFailed
+			}
+		}
+	}
+
+	for _, check := range checks {
+		for i := range check.Results {
+			if check.Results[i] == "" {
+				check.Results[i] = CheckIdEmptyValue
+			}
+		}
+		trend.Checks = append(trend.Checks, check)
+	}
+	sort.Slice(trend.Checks, func(i, j int) bool { return trend.Checks[i].ID < trend.Checks[j].ID })
+
+	for _, counter := range counters {
+		trend.ErrorCounters = append(trend.ErrorCounters, counter)
+	}
+	sort.Slice(trend.ErrorCounters, func(i, j int) bool {
+		if trend.ErrorCounters[i].Source != trend.ErrorCounters[j].Source {
+			return trend.ErrorCounters[i].Source > trend.ErrorCounters[j].Source
+		}
+		return trend.ErrorCounters[i].Name < trend.ErrorCounters[j].Name
+	})
+
+	// Tests failing in all runs of the plugin are consistent failures, not reported.
+	for _, test := range tests {
+		for i, run := range trend.Runs {
+			switch {
+			case test.Results[i] == TrendTestFailed:
+				test.Failures++
+				test.Runs++
+			case run.GetPlugin(test.Plugin) == nil:
+				test.Results[i] = CheckIdEmptyValue
+			default:
+				test.Results[i] = TrendTestPassed
+				test.Runs++
+			}
+		}
+		if test.Failures < test.Runs {
+			trend.Intermittent = append(trend.Intermittent, test)
+		}
+	}
+	sort.Slice(trend.Intermittent, func(i, j int) bool {
+		if trend.Intermittent[i].Plugin != trend.Intermittent[j].Plugin {
+			return trend.Intermittent[i].Plugin < trend.Intermittent[j].Plugin
+		}
+		return trend.Intermittent[i].Name < trend.Intermittent[j].Name
+	})
+	return trend, nil
+}
+
+// RenderTable writes the trend as CLI tables.
+func (t *ReportTrend) RenderTable(w io.Writer) {
+	tbRuns := table.NewWriter()
+	tbRuns.SetTitle("Runs (ordered by execution date)")
+	header := table.Row{"#", "Run", "Date", "Version"}
+	for _, name := range plugin.ConformancePlugins {
+		header = append(header, fmt.Sprintf("%s\npass ratio (filtered failures)", name))
+	}
+	header = append(header, "Checks\npass/fail/warn/skip/waived", "etcd p99 (ms)")
+	tbRuns.AppendHeader(header)
+	for i, run := range t.Runs {
+		row := table.Row{i + 1, run.Name, run.ExecutionDate, run.OpenShiftVersion}
+		for _, name := range plugin.ConformancePlugins {
+			row = append(row, t.formatPlugin(run.GetPlugin(name)))
+		}
+		row = append(row, fmt.Sprintf("%d/%d/%d/%d/%d", run.ChecksPass, run.ChecksFail, run.ChecksWarn, run.ChecksSkip, run.ChecksWaived), formatEtcdP99(run.EtcdP99))
+		tbRuns.AppendRow(row)
+	}
+	fmt.Fprintln(w, tbRuns.Render())
+	fmt.Fprintln(w)
+
+	runsHeader := func(prefix ...interface{}) table.Row {
+		row := table.Row(prefix)
+		for i := range t.Runs {
+			row = append(row, fmt.Sprintf("#%d", i+1))
+		}
+		return row
+	}
+
+	tbChecks := table.NewWriter()
+	tbChecks.SetTitle("Checks")
+	tbChecks.AppendHeader(runsHeader("ID", "Check"))
+	for _, c := range t.Checks {
+		row := table.Row{c.ID, c.Name}
+		for _, r := range c.Results {
+			row = append(row, r)
+		}
+		tbChecks.AppendRow(row)
+	}
+	fmt.Fprintln(w, tbChecks.Render())
+	fmt.Fprintln(w)
+
+	tbCounters := table.NewWriter()
+	tbCounters.SetTitle("Error counters")
+	tbCounters.AppendHeader(runsHeader("Source", "Error"))
+	for _, c := range t.ErrorCounters {
+		row := table.Row{c.Source, c.Name}
+		for _, v := range c.Values {
+			row = append(row, v)
+		}
+		tbCounters.AppendRow(row)
+	}
+	fmt.Fprintln(w, tbCounters.Render())
+	fmt.Fprintln(w)
+
+	tbTests := table.NewWriter()
+	tbTests.SetTitle("Intermittent failures (failing in some runs but not others)")
+	tbTests.AppendHeader(runsHeader("Plugin", "Test", "Failures"))
+	for _, test := range t.Intermittent {
+		row := table.Row{test.Plugin, test.Name, fmt.Sprintf("%d/%d", test.Failures, test.Runs)}
+		for _, r := range test.Results {
+			row = append(row, r)
+		}
+		tbTests.AppendRow(row)
+	}
+	fmt.Fprintln(w, tbTests.Render())
+}
+
+// formatPlugin returns the pass ratio and the filtered failures of the plugin.
+func (t *ReportTrend) formatPlugin(p *TrendPlugin) string {
+	if p == nil {
+		return CheckIdEmptyValue
+	}
+	return fmt.Sprintf("%.2f%% (%d)", p.PassRatio, p.FailedFiltered)
+}
+
+func formatEtcdP99(p99 *float64) string {
+	if p99 == nil {
+		return CheckIdEmptyValue
+	}
+	return fmt.Sprintf("%.3f", *p99)
+}
+
+// RenderHTML writes the trend as a HTML page, rendered from the template trend.html.
+func (t *ReportTrend) RenderHTML(w io.Writer) error {
+	src := fmt.Sprintf("%s/%s", ReportTemplateBasePath, TrendTemplateHTML)
+	data, err := vfs.GetData().ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("unable to read file %q from VFS: %v", src, err)
+	}
+	return t.renderHTML(w, data)
+}
+
+func (t *ReportTrend) renderHTML(w io.Writer, tmplData []byte) error {
+	funcs := template.FuncMap{
+		"plugins":      func() []string { return plugin.ConformancePlugins },
+		"formatPlugin": t.formatPlugin,
+		"formatP99":    formatEtcdP99,
+		"inc":          func(i int) int { return i + 1 },
+		"resultClass": func(result string) string {
+			switch result {
+			case TrendTestFailed:
+				return "table-danger"
+			case string(CheckResultNameWarn), string(CheckResultNameWaived):
+				return "table-warning"
+			case TrendTestPassed:
+				return "table-success"
+			}
+			return ""
+		},
+	}
+	// Same template delimiter of the report pages.
+	tmpl, err := template.New("trend").Delims("[[", "]]").Funcs(funcs).Parse(string(tmplData))
+	if err != nil {
+		return fmt.Errorf("unable to create template for the trend: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t); err != nil {
+		return fmt.Errorf("unable to process template for the trend: %v", err)
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
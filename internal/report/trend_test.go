@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/archive"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+)
+
+func TestNewReportTrend(t *testing.T) {
+	newRun := func(date string, failed int64, failures []string, check string, p99 string) *ReportData {
+		re := newDiffReport(100, failed, failures, map[string]string{"OPCT-004": check})
+		re.Setup = &ReportSetup{API: &ReportSetupAPI{ExecutionDate: date, OpenShiftVersion: "4.16.0"}}
+		re.Provider.ErrorCounters = &archive.ErrorCounter{"e2e timeout": int(failed)}
+		re.Provider.MustGatherInfo = &mustgather.MustGather{
+			ErrorEtcdLogs: &mustgather.ErrorEtcdLogs{
+				FilterRequestSlowAll: map[string]*mustgather.BucketFilterStat{"all": {StatPerc99: p99}},
+			},
+		}
+		return re
+	}
+	// arguments are not in the execution order.
+	names := []string{"run3", "run1", "run2"}
+	reports := []*ReportData{
+		newRun("2024-01-03T10:00:00Z", 1, []string{"t1"}, "pass", "300.000 (ms)"),
+		newRun("2024-01-01T10:00:00Z", 2, []string{"t1", "t2"}, "fail", "100.000 (ms)"),
+		newRun("2024-01-02T10:00:00Z", 1, []string{"t1"}, "pass", ""),
+	}
+
+	trend, err := NewReportTrend(names, reports)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"run3", "run1", "run2"}, names, "arguments are not changed")
+
+	assert.Len(t, trend.Runs, 3)
+	assert.Equal(t, "run1", trend.Runs[0].Name)
+	assert.Equal(t, "run3", trend.Runs[2].Name)
+	p := trend.Runs[0].GetPlugin(plugin.PluginNameOpenShiftConformance)
+	assert.Equal(t, 98.0, p.PassRatio)
+	assert.Equal(t, int64(2), p.FailedFiltered)
+	assert.Equal(t, 1, trend.Runs[0].ChecksFail)
+	assert.Equal(t, 100.0, *trend.Runs[0].EtcdP99)
+	assert.Nil(t, trend.Runs[1].EtcdP99)
+
+	assert.Equal(t, []*TrendCheck{{ID: "OPCT-004", Name: "check OPCT-004", Results: []string{"fail", "pass", "pass"}}}, trend.Checks)
+	assert.Equal(t, []*TrendCounter{{Source: DiffErrorSourceTests, Name: "e2e timeout", Values: []int{2, 1, 1}}}, trend.ErrorCounters)
+
+	// t1 fails in all runs, t2 is intermittent.
+	assert.Equal(t, []*TrendTest{{
+		Plugin:   plugin.PluginNameOpenShiftConformance,
+		Name:     "t2",
+		Failures: 1,
+		Runs:     3,
+		Results:  []string{TrendTestFailed, TrendTestPassed, TrendTestPassed},
+	}}, trend.Intermittent)
+
+	// runs without execution date keep the order of the arguments.
+	reports[1].Setup.API.ExecutionDate = ""
+	trend, err = NewReportTrend(names, reports)
+	assert.NoError(t, err)
+	assert.Equal(t, "run3", trend.Runs[0].Name)
+
+	_, err = NewReportTrend([]string{"run1"}, reports)
+	assert.Error(t, err)
+}
+
+func TestReportTrendRender(t *testing.T) {
+	reports := []*ReportData{
+		newDiffReport(100, 1, []string{"t1"}, map[string]string{"OPCT-004": "fail"}),
+		newDiffReport(100, 0, nil, map[string]string{"OPCT-004": "pass"}),
+	}
+	trend, err := NewReportTrend([]string{"run1", "run2"}, reports)
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	trend.RenderTable(buf)
+	assert.Contains(t, buf.String(), "Intermittent failures")
+	assert.Contains(t, buf.String(), "99.00% (1)")
+
+	tmpl, err := os.ReadFile("../../data/templates/report/" + TrendTemplateHTML)
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, trend.renderHTML(buf, tmpl))
+	assert.Contains(t, buf.String(), "<td>99.00% (1)</td>")
+	assert.Contains(t, buf.String(), `<td class="table-danger">fail</td>`)
+	assert.Contains(t, buf.String(), "<td>t1</td><td>1/2</td>")
+}
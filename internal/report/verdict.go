@@ -0,0 +1,123 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check results failing the report (opct report --fail-on).
+const (
+	FailOnFail = "fail"
+	FailOnWarn = "warn"
+	FailOnNone = "none"
+)
+
+// Exit codes of 'opct report'. The first matching code, in the order below, is returned.
+const (
+	// ExitCodeOK is returned when the report is processed and no gating rule matches.
+	ExitCodeOK = 0
+
+	// ExitCodeError is returned when the report processing fails.
+	ExitCodeError = 1
+
+	// ExitCodeChecksFailed is returned when one or more checks failed (--fail-on=fail|warn).
+	ExitCodeChecksFailed = 2
+
+	// ExitCodeChecksWarned is returned when one or more checks are in warning (--fail-on=warn).
+	ExitCodeChecksWarned = 3
+
+	// ExitCodeCheckSelected is returned when one or more checks of --fail-on-check failed.
+	ExitCodeCheckSelected = 4
+)
+
+// Verdict is the gating result of the checks, used by CI pipelines.
+type Verdict struct {
+	Status   string
+	ExitCode int
+	FailOn   string
+
+	Pass   int
+	Fail   int
+	Warn   int
+	Skip   int
+	Waived int
+
+	// FailedChecks are the IDs of the checks failing the verdict.
+	FailedChecks []string
+}
+
+// ValidateFailOn returns an error when the value of --fail-on is not supported.
+func ValidateFailOn(failOn string) error {
+	switch failOn {
+	case FailOnFail, FailOnWarn, FailOnNone:
+		return nil
+	}
+	return fmt.Errorf("invalid value %q, supported: %s, %s, %s", failOn, FailOnFail, FailOnWarn, FailOnNone)
+}
+
+// NewVerdict maps the check results to the verdict and the exit code:
+// failOn sets the check results failing the report (fail, warn or none), and
+// failOnChecks the check IDs failing the report, independently of failOn. Check IDs
+// not found in the report (any result) return an error, exiting with ExitCodeError.
+func NewVerdict(checks *ReportChecks, failOn string, failOnChecks []string) (*Verdict, error) {
+	if err := ValidateFailOn(failOn); err != nil {
+		return nil, err
+	}
+	if checks == nil {
+		return nil, fmt.Errorf("checks are not available in the report")
+	}
+	unknown := []string{}
+	for _, id := range failOnChecks {
+		if check, _ := findCheck(checks, id); check == nil {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown check IDs in --fail-on-check: %s", strings.Join(unknown, ", "))
+	}
+	v := &Verdict{Status: "pass", ExitCode: ExitCodeOK, FailOn: failOn, FailedChecks: []string{}}
+	v.Pass, v.Fail, v.Warn = len(checks.Pass), len(checks.Fail), len(checks.Warn)
+	v.Skip, v.Waived = len(checks.Skip), len(checks.Waived)
+
+	switch {
+	case failOn != FailOnNone && len(checks.Fail) > 0:
+		v.Status = "fail"
+		v.ExitCode = ExitCodeChecksFailed
+		v.FailedChecks = checkIDs(checks.Fail)
+	case failOn == FailOnWarn && len(checks.Warn) > 0:
+		v.Status = "warn"
+		v.ExitCode = ExitCodeChecksWarned
+		v.FailedChecks = checkIDs(checks.Warn)
+	default:
+		for _, id := range failOnChecks {
+			if _, result := findCheck(checks, id); result == CheckResultNameFail {
+				v.FailedChecks = append(v.FailedChecks, id)
+			}
+		}
+		if len(v.FailedChecks) > 0 {
+			v.Status = "fail"
+			v.ExitCode = ExitCodeCheckSelected
+		}
+	}
+	return v, nil
+}
+
+// checkIDs returns the IDs of the checks, the name is used for checks without ID.
+func checkIDs(outputs []*SLOOutput) []string {
+	ids := []string{}
+	for _, o := range outputs {
+		if o.ID == CheckIdEmptyValue {
+			ids = append(ids, fmt.Sprintf("%q", o.SLO))
+			continue
+		}
+		ids = append(ids, o.ID)
+	}
+	return ids
+}
+
+// String returns the machine-readable verdict line.
+// Example: verdict: status=fail exitCode=2 failOn=fail pass=20 fail=1 warn=2 skip=1 waived=0 failedChecks=OPCT-001
+func (v *Verdict) String() string {
+	return fmt.Sprintf("verdict: status=%s exitCode=%d failOn=%s pass=%d fail=%d warn=%d skip=%d waived=%d failedChecks=%s",
+		v.Status, v.ExitCode, v.FailOn, v.Pass, v.Fail, v.Warn, v.Skip, v.Waived, strings.Join(v.FailedChecks, ","))
+}
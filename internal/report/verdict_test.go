@@ -0,0 +1,54 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerdict(t *testing.T) {
+	checks := &ReportChecks{
+		Fail: []*SLOOutput{{ID: CheckID004}, {ID: CheckIdEmptyValue, SLO: "Cluster upgrade must not be Progressing"}},
+		Warn: []*SLOOutput{{ID: "OPCT-010A"}},
+		Pass: []*SLOOutput{{ID: CheckID001}},
+	}
+	passing := &ReportChecks{
+		Warn: []*SLOOutput{{ID: "OPCT-010A"}},
+		Pass: []*SLOOutput{{ID: CheckID001}},
+	}
+	tests := []struct {
+		name     string
+		checks   *ReportChecks
+		failOn   string
+		selected []string
+		exitCode int
+		failed   []string
+	}{
+		{"none", checks, FailOnNone, nil, ExitCodeOK, []string{}},
+		{"fail", checks, FailOnFail, nil, ExitCodeChecksFailed, []string{CheckID004, `"Cluster upgrade must not be Progressing"`}},
+		{"warn with failures", checks, FailOnWarn, nil, ExitCodeChecksFailed, []string{CheckID004, `"Cluster upgrade must not be Progressing"`}},
+		{"warn", passing, FailOnWarn, nil, ExitCodeChecksWarned, []string{"OPCT-010A"}},
+		{"fail without failures", passing, FailOnFail, nil, ExitCodeOK, []string{}},
+		{"selected check failed", checks, FailOnNone, []string{CheckID001, CheckID004}, ExitCodeCheckSelected, []string{CheckID004}},
+		{"selected check passed", checks, FailOnNone, []string{CheckID001, "OPCT-010A"}, ExitCodeOK, []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := NewVerdict(tc.checks, tc.failOn, tc.selected)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exitCode, v.ExitCode)
+			assert.Equal(t, tc.failed, v.FailedChecks)
+		})
+	}
+
+	v, err := NewVerdict(checks, FailOnFail, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `verdict: status=fail exitCode=2 failOn=fail pass=1 fail=2 warn=1 skip=0 waived=0 failedChecks=OPCT-004,"Cluster upgrade must not be Progressing"`, v.String())
+
+	_, err = NewVerdict(checks, "always", nil)
+	assert.Error(t, err)
+
+	// unknown check IDs must not disable the gate.
+	_, err = NewVerdict(checks, FailOnNone, []string{CheckID004, "OPCT-04", "OPCT-999"})
+	assert.EqualError(t, err, "unknown check IDs in --fail-on-check: OPCT-04, OPCT-999")
+}
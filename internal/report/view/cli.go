@@ -1,4 +1,666 @@
 package view
 
-// TODO: implement the CLI view for report.
-// Views should not have any logic.
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	table "github.com/jedib0t/go-pretty/v6/table"
+	tabletext "github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware-tanzu/sonobuoy/pkg/errlog"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+var iconsCollor = map[string]string{
+	"pass":   "✅",
+	"passed": "✅",
+	"fail":   "❌",
+	"failed": "❌",
+	"warn":   "⚠️", // there is a bug, the emoji is rendered breaking the table
+	"alert":  "🚨",
+	"waived": "⊘",
+}
+
+var iconsBW = map[string]string{
+	"pass":   "✔",
+	"passed": "✔",
+	"fail":   "✖",
+	"failed": "✖",
+	"warn":   "⚠",
+	"alert":  "⚠",
+	"waived": "⊘",
+}
+
+// CLI renders the report as tables in the terminal.
+type CLI struct {
+	w       io.Writer
+	verbose bool
+}
+
+// NewCLI returns the CLI view writing to w, showing the failures excluded by
+// each filter when verbose.
+func NewCLI(w io.Writer, verbose bool) *CLI {
+	return &CLI{w: w, verbose: verbose}
+}
+
+// Render writes the report as CLI tables: aggregated summary, processed summary by plugin,
+// failures to review and the checks.
+func (v *CLI) Render(re *report.ReportData) error {
+	if err := showReportAggregatedSummary(v.w, re); err != nil {
+		return fmt.Errorf("error showing aggregated summary: %v", err)
+	}
+	if err := showProcessedSummary(v.w, re); err != nil {
+		return fmt.Errorf("error showing processed summary: %v", err)
+	}
+	if err := showErrorDetails(v.w, re, v.verbose); err != nil {
+		return fmt.Errorf("error showing error details: %v", err)
+	}
+	if err := showChecks(v.w, re); err != nil {
+		return fmt.Errorf("error showing checks: %v", err)
+	}
+	return nil
+}
+
+func showReportAggregatedSummary(w io.Writer, re *report.ReportData) error {
+	baselineProcessed := re.Baseline != nil
+
+	// Using go-table
+	archive := filepath.Base(re.Summary.Tests.Archive)
+	if re.Baseline != nil {
+		archive = fmt.Sprintf("%s\n >> Diff from: %s", archive, filepath.Base(re.Summary.Tests.ArchiveDiff))
+	}
+	title := "OPCT Summary\n > Archive: " + archive
+
+	// standalone results (provider)
+	tbProv := table.NewWriter()
+	tbProv.SetOutputMirror(w)
+	tbProv.SetStyle(table.StyleLight)
+	tbProv.SetTitle(title)
+	tbProv.AppendHeader(table.Row{"", "Provider"})
+
+	// baseline results (provider+baseline)
+	tbPBas := table.NewWriter()
+	tbPBas.SetOutputMirror(w)
+	tbPBas.SetStyle(table.StyleLight)
+	tbPBas.SetTitle(title)
+	tbPBas.AppendHeader(table.Row{"", "Provider", "Baseline"})
+	rowsPBas := []table.Row{}
+
+	// Section: Cluster configuration
+	joinPlatformType := func(infra *report.ReportInfra) string {
+		tp := infra.PlatformType
+		if tp == "External" {
+			tp = fmt.Sprintf("%s (%s)", tp, infra.PlatformName)
+		}
+		return tp
+	}
+	rowsProv := []table.Row{{"Infrastructure:", ""}}
+	rowsProv = append(rowsProv, table.Row{" PlatformType", joinPlatformType(re.Provider.Infra)})
+	rowsProv = append(rowsProv, table.Row{" Name", re.Provider.Infra.Name})
+	rowsProv = append(rowsProv, table.Row{" ClusterID", re.Provider.Version.OpenShift.ClusterID})
+	rowsProv = append(rowsProv, table.Row{" Topology", re.Provider.Infra.Topology})
+	rowsProv = append(rowsProv, table.Row{" ControlPlaneTopology", re.Provider.Infra.ControlPlaneTopology})
+	rowsProv = append(rowsProv, table.Row{" API Server URL", re.Provider.Infra.APIServerURL})
+	rowsProv = append(rowsProv, table.Row{" API Server URL (internal)", re.Provider.Infra.APIServerInternalURL})
+	rowsProv = append(rowsProv, table.Row{" NetworkType", re.Provider.Infra.NetworkType})
+	tbProv.AppendRows(rowsProv)
+	tbProv.AppendSeparator()
+	if baselineProcessed {
+		rowsPBas = []table.Row{{"Infrastructure:", "", ""}}
+		rowsPBas = append(rowsPBas, table.Row{" PlatformType", joinPlatformType(re.Provider.Infra), joinPlatformType(re.Baseline.Infra)})
+		rowsPBas = append(rowsPBas, table.Row{" Name", re.Provider.Infra.Name, re.Baseline.Infra.Name})
+		rowsPBas = append(rowsPBas, table.Row{" Topology", re.Provider.Infra.Topology, re.Baseline.Infra.Topology})
+		rowsPBas = append(rowsPBas, table.Row{" ControlPlaneTopology", re.Provider.Infra.ControlPlaneTopology, re.Baseline.Infra.ControlPlaneTopology})
+		rowsPBas = append(rowsPBas, table.Row{" API Server URL", re.Provider.Infra.APIServerURL, re.Baseline.Infra.APIServerURL})
+		rowsPBas = append(rowsPBas, table.Row{" API Server URL (internal)", re.Provider.Infra.APIServerInternalURL, re.Baseline.Infra.APIServerInternalURL})
+		rowsPBas = append(rowsPBas, table.Row{" NetworkType", re.Baseline.Infra.NetworkType})
+		tbPBas.AppendRows(rowsPBas)
+		tbPBas.AppendSeparator()
+	}
+
+	// Section: Cluster state
+	rowsProv = []table.Row{{"Cluster Version:", ""}}
+	rowsProv = append(rowsProv, table.Row{" Kubernetes", re.Provider.Version.Kubernetes})
+	rowsProv = append(rowsProv, table.Row{" OpenShift", re.Provider.Version.OpenShift.Desired})
+	rowsProv = append(rowsProv, table.Row{" Channel", re.Provider.Version.OpenShift.Channel})
+	tbProv.AppendRows(rowsProv)
+	tbProv.AppendSeparator()
+	rowsProv = []table.Row{{"Cluster Status: ", re.Provider.Version.OpenShift.OverallStatus}}
+	if re.Provider.Version.OpenShift.OverallStatus != "Available" {
+		rowsProv = append(rowsProv, table.Row{" Reason", re.Provider.Version.OpenShift.OverallStatusReason})
+		rowsProv = append(rowsProv, table.Row{" Message", re.Provider.Version.OpenShift.OverallStatusMessage})
+	}
+	rowsProv = append(rowsProv, table.Row{"Cluster Status/Conditions:", ""})
+	rowsProv = append(rowsProv, table.Row{" Available", re.Provider.Version.OpenShift.CondAvailable})
+	rowsProv = append(rowsProv, table.Row{" Failing", re.Provider.Version.OpenShift.CondFailing})
+	rowsProv = append(rowsProv, table.Row{" Progressing (Update)", re.Provider.Version.OpenShift.CondProgressing})
+	rowsProv = append(rowsProv, table.Row{" RetrievedUpdates", re.Provider.Version.OpenShift.CondRetrievedUpdates})
+	rowsProv = append(rowsProv, table.Row{" EnabledCapabilities", re.Provider.Version.OpenShift.CondImplicitlyEnabledCapabilities})
+	rowsProv = append(rowsProv, table.Row{" ReleaseAccepted", re.Provider.Version.OpenShift.CondReleaseAccepted})
+	tbProv.AppendRows(rowsProv)
+	tbProv.AppendSeparator()
+
+	if baselineProcessed {
+		rowsPBas = []table.Row{{"Cluster Version:", "", ""}}
+		rowsPBas = append(rowsPBas, table.Row{" Kubernetes", re.Provider.Version.Kubernetes, re.Baseline.Version.Kubernetes})
+		rowsPBas = append(rowsPBas, table.Row{" OpenShift", re.Provider.Version.OpenShift.Desired, re.Baseline.Version.OpenShift.Desired})
+		rowsPBas = append(rowsPBas, table.Row{" Channel", re.Provider.Version.OpenShift.Channel, re.Baseline.Version.OpenShift.Channel})
+		tbPBas.AppendRows(rowsPBas)
+		tbPBas.AppendSeparator()
+		rowsPBas = []table.Row{{"Cluster Status: ", re.Provider.Version.OpenShift.OverallStatus, re.Baseline.Version.OpenShift.OverallStatus}}
+		if re.Provider.Version.OpenShift.OverallStatus != "Available" {
+			rowsPBas = append(rowsPBas, table.Row{" Reason", re.Provider.Version.OpenShift.OverallStatusReason, re.Baseline.Version.OpenShift.OverallStatusReason})
+			rowsPBas = append(rowsPBas, table.Row{" Message", re.Provider.Version.OpenShift.OverallStatusMessage, re.Baseline.Version.OpenShift.OverallStatusMessage})
+		}
+		rowsPBas = append(rowsPBas, table.Row{"Cluster Status/Conditions:", "", ""})
+		rowsPBas = append(rowsPBas, table.Row{" Available", re.Provider.Version.OpenShift.CondAvailable, re.Baseline.Version.OpenShift.CondAvailable})
+		rowsPBas = append(rowsPBas, table.Row{" Failing", re.Provider.Version.OpenShift.CondFailing, re.Baseline.Version.OpenShift.CondFailing})
+		rowsPBas = append(rowsPBas, table.Row{" Progressing (Update)", re.Provider.Version.OpenShift.CondProgressing, re.Baseline.Version.OpenShift.CondProgressing})
+		rowsPBas = append(rowsPBas, table.Row{" RetrievedUpdates", re.Provider.Version.OpenShift.CondRetrievedUpdates, re.Baseline.Version.OpenShift.CondRetrievedUpdates})
+		rowsPBas = append(rowsPBas, table.Row{" EnabledCapabilities", re.Provider.Version.OpenShift.CondImplicitlyEnabledCapabilities, re.Baseline.Version.OpenShift.CondImplicitlyEnabledCapabilities})
+		rowsPBas = append(rowsPBas, table.Row{" ReleaseAccepted", re.Provider.Version.OpenShift.CondReleaseAccepted, re.Baseline.Version.OpenShift.CondReleaseAccepted})
+		tbPBas.AppendRows(rowsPBas)
+		tbPBas.AppendSeparator()
+	}
+
+	// Section: Environment state
+	rowsProv = []table.Row{{"Plugin summary:", "Status [Total/Passed/Failed/Skipped] (timeout)"}}
+	if baselineProcessed {
+		rowsPBas = []table.Row{{"Plugin summary:", "Status [Total/Passed/Failed/Skipped] (timeout)", ""}}
+	}
+
+	showPluginSummary := func(w *tabwriter.Writer, pluginName string) {
+		if _, ok := re.Provider.Plugins[pluginName]; !ok {
+			errlog.LogError(errors.New(fmt.Sprintf("Unable to load plugin %s", pluginName)))
+		}
+		plK8S := re.Provider.Plugins[pluginName]
+		name := fmt.Sprintf(" %s", plK8S.Name)
+		stat := plK8S.Stat
+		pOCPPluginRes := fmt.Sprintf("%s [%d/%d/%d/%d] (%d)", stat.Status, stat.Total, stat.Passed, stat.Failed, stat.Skipped, stat.Timeout)
+		rowsProv = append(rowsProv, table.Row{name, pOCPPluginRes})
+		if baselineProcessed {
+			plK8S = re.Baseline.Plugins[pluginName]
+			stat := plK8S.Stat
+			bOCPPluginRes := fmt.Sprintf("%s [%d/%d/%d/%d] (%d)", stat.Status, stat.Total, stat.Passed, stat.Failed, stat.Skipped, stat.Timeout)
+			// fmt.Fprintf(tbWriter, " - %s\t: %s\t: %s\n", name, pOCPPluginRes, bOCPPluginRes)
+			rowsPBas = append(rowsPBas, table.Row{name, pOCPPluginRes, bOCPPluginRes})
+		}
+	}
+
+	showPluginSummary(nil, plugin.PluginNameKubernetesConformance)
+	showPluginSummary(nil, plugin.PluginNameOpenShiftConformance)
+	showPluginSummary(nil, plugin.PluginNameOpenShiftUpgrade)
+
+	if b := re.Summary.BaselineAPI; b != nil && len(b.Baselines) > 0 {
+		rowsProv = append(rowsProv, table.Row{" Baseline results", fmt.Sprintf("%d (min failure share %.2f)", len(b.Baselines), b.MinFailureShare)})
+	}
+	if fds := re.Summary.FlakeDataSource; fds != nil {
+		flakeSource := fmt.Sprintf("%s [api=%d cache=%d snapshot=%d stale=%d unavailable=%d]",
+			fds.Source, fds.API, fds.Cache, fds.Snapshot, fds.Stale, fds.Unavailable)
+		rowsProv = append(rowsProv, table.Row{" Flake data source", flakeSource})
+		if baselineProcessed {
+			rowsPBas = append(rowsPBas, table.Row{" Flake data source", flakeSource, ""})
+		}
+	}
+
+	tbProv.AppendRows(rowsProv)
+	tbProv.AppendSeparator()
+	rowsProv = []table.Row{{"Env health summary:", "[A=True/P=True/D=True]"}}
+	if baselineProcessed {
+		tbPBas.AppendRows(rowsPBas)
+		tbPBas.AppendSeparator()
+		rowsPBas = []table.Row{{"Env health summary:", "[A=True/P=True/D=True]", ""}}
+	}
+
+	pOCPCO := re.Provider.ClusterOperators
+	rowsProv = append(rowsProv, table.Row{
+		" Cluster Operators",
+		fmt.Sprintf("[%d/%d/%d]", pOCPCO.CountAvailable, pOCPCO.CountProgressing, pOCPCO.CountDegraded),
+	})
+	if baselineProcessed {
+		bOCPCO := re.Baseline.ClusterOperators
+		rowsPBas = append(rowsPBas, table.Row{
+			" Cluster Operators",
+			fmt.Sprintf("[%d/%d/%d]", pOCPCO.CountAvailable, pOCPCO.CountProgressing, pOCPCO.CountDegraded),
+			fmt.Sprintf("[%d/%d/%d]", bOCPCO.CountAvailable, bOCPCO.CountProgressing, bOCPCO.CountDegraded),
+		})
+	}
+
+	// Show Nodes Health info collected by Sonobuoy
+	pNhMessage := fmt.Sprintf("%d/%d %s", re.Provider.ClusterHealth.NodeHealthy, re.Provider.ClusterHealth.NodeHealthTotal, "")
+	if re.Provider.ClusterHealth.NodeHealthTotal != 0 {
+		pNhMessage = fmt.Sprintf("%s (%.2f%%)", pNhMessage, re.Provider.ClusterHealth.NodeHealthPerc)
+	}
+
+	rowsProv = append(rowsProv, table.Row{" Node health", pNhMessage})
+	if baselineProcessed {
+		bNhMessage := fmt.Sprintf("%d/%d %s", re.Baseline.ClusterHealth.NodeHealthy, re.Baseline.ClusterHealth.NodeHealthTotal, "")
+		if re.Baseline.ClusterHealth.NodeHealthTotal != 0 {
+			bNhMessage = fmt.Sprintf("%s (%.2f%%)", bNhMessage, re.Baseline.ClusterHealth.NodeHealthPerc)
+		}
+		rowsPBas = append(rowsPBas, table.Row{" Node health", pNhMessage, bNhMessage})
+	}
+
+	// Show Pods Health info collected by Sonobuoy
+	pPodsHealthMsg := ""
+	bPodsHealthMsg := ""
+	phTotal := ""
+
+	if re.Provider.ClusterHealth.PodHealthTotal != 0 {
+		phTotal = fmt.Sprintf(" (%.2f%%)", re.Provider.ClusterHealth.PodHealthPerc)
+	}
+	pPodsHealthMsg = fmt.Sprintf("%d/%d %s", re.Provider.ClusterHealth.PodHealthy, re.Provider.ClusterHealth.PodHealthTotal, phTotal)
+	rowsProv = append(rowsProv, table.Row{" Pods health", pPodsHealthMsg})
+	if baselineProcessed {
+		phTotal := ""
+		if re.Baseline.ClusterHealth.PodHealthTotal != 0 {
+			phTotal = fmt.Sprintf(" (%.2f%%)", re.Baseline.ClusterHealth.PodHealthPerc)
+		}
+		bPodsHealthMsg = fmt.Sprintf("%d/%d %s", re.Baseline.ClusterHealth.PodHealthy, re.Baseline.ClusterHealth.PodHealthTotal, phTotal)
+		rowsPBas = append(rowsPBas, table.Row{" Pods health", pPodsHealthMsg, bPodsHealthMsg})
+	}
+
+	// Section: Test count by suite
+	tbProv.AppendRows(rowsProv)
+	tbProv.AppendSeparator()
+	rowsProv = []table.Row{{"Test count by suite:", ""}}
+	if baselineProcessed {
+		tbPBas.AppendRows(rowsPBas)
+		tbPBas.AppendSeparator()
+		rowsPBas = []table.Row{{"Test count by suite:", "", ""}}
+	}
+
+	checkEmpty := func(counter int) string {
+		if counter == 0 {
+			return "(FAIL)"
+		}
+		return ""
+	}
+	rowsProv = append(rowsProv, table.Row{
+		summary.SuiteNameKubernetesConformance,
+		fmt.Sprintf("%d %s",
+			re.Provider.Plugins[plugin.PluginNameKubernetesConformance].Suite.Count,
+			checkEmpty(re.Provider.Plugins[plugin.PluginNameKubernetesConformance].Suite.Count),
+		),
+	})
+	rowsProv = append(rowsProv, table.Row{
+		summary.SuiteNameOpenshiftConformance,
+		fmt.Sprintf("%d %s",
+			re.Provider.Plugins[plugin.PluginNameOpenShiftConformance].Suite.Count,
+			checkEmpty(re.Provider.Plugins[plugin.PluginNameOpenShiftConformance].Suite.Count),
+		),
+	})
+	if baselineProcessed {
+		p := re.Baseline.Plugins[plugin.PluginNameKubernetesConformance]
+		if p != nil && p.Suite != nil {
+			rowsPBas = append(rowsPBas, table.Row{
+				summary.SuiteNameKubernetesConformance,
+				fmt.Sprintf("%d %s",
+					re.Provider.Plugins[plugin.PluginNameKubernetesConformance].Suite.Count,
+					checkEmpty(re.Provider.Plugins[plugin.PluginNameKubernetesConformance].Suite.Count),
+				),
+				fmt.Sprintf("%d %s", p.Suite.Count, checkEmpty(p.Suite.Count)),
+			})
+		}
+		p = re.Baseline.Plugins[plugin.PluginNameOpenShiftConformance]
+		if p != nil && p.Suite != nil {
+			rowsPBas = append(rowsPBas, table.Row{
+				summary.SuiteNameOpenshiftConformance,
+				fmt.Sprintf("%d %s",
+					re.Provider.Plugins[plugin.PluginNameOpenShiftConformance].Suite.Count,
+					checkEmpty(re.Provider.Plugins[plugin.PluginNameOpenShiftConformance].Suite.Count),
+				),
+				fmt.Sprintf("%d %s", p.Suite.Count, checkEmpty(p.Suite.Count)),
+			})
+		}
+	}
+
+	// Decide which table to show.
+	if baselineProcessed {
+		// Table done (provider + baseline)
+		tbPBas.AppendRows(rowsPBas)
+		tbPBas.Render()
+	} else {
+		// Table done (provider)
+		tbProv.AppendRows(rowsProv)
+		tbProv.Render()
+	}
+
+	// Section: Failed pods counter (using old table version [tabwritter])
+	newLineWithTab := "\t\t\n"
+	tbWriter := tabwriter.NewWriter(w, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprint(tbWriter, newLineWithTab)
+	if len(re.Provider.ClusterHealth.PodHealthDetails) > 0 {
+		fmt.Fprintf(tbWriter, " Failed pods:\n")
+		fmt.Fprintf(tbWriter, "  %s/%s\t%s\t%s\t%s\t%s\n", "Namespace", "PodName", "Healthy", "Ready", "Reason", "Message")
+		for _, podDetails := range re.Provider.ClusterHealth.PodHealthDetails {
+			fmt.Fprintf(tbWriter, "  %s/%s\t%t\t%s\t%s\t%s\n", podDetails.Namespace, podDetails.Name, podDetails.Healthy, podDetails.Ready, podDetails.Reason, podDetails.Message)
+		}
+	}
+	tbWriter.Flush()
+
+	return nil
+}
+
+func showProcessedSummary(w io.Writer, re *report.ReportData) error {
+	fmt.Fprintf(w, "\n=> Processed Summary <=\n")
+	fmt.Fprintf(w, "==> Result Summary by test suite:\n")
+	bProcessed := re.Provider.HasValidBaseline
+	plugins := re.Provider.GetPlugins()
+	sort.Strings(plugins)
+	for _, pluginName := range plugins {
+		showSummaryPlugin(w, re.Provider, pluginName, bProcessed)
+	}
+	return nil
+}
+
+func showSummaryPlugin(w io.Writer, re *report.ReportResult, pluginName string, bProcessed bool) {
+	if re.Plugins[pluginName] == nil {
+		log.Errorf("unable to get plugin %s", pluginName)
+		return
+	}
+	p := re.Plugins[pluginName]
+	if p.Stat == nil {
+		log.Errorf("unable to get stat for plugin %s", pluginName)
+		return
+	}
+
+	tb := table.NewWriter()
+	tb.SetOutputMirror(w)
+	tb.SetStyle(table.StyleLight)
+	title := fmt.Sprintf("%s:", p.Name)
+	titleIcon := ""
+	tb.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, WidthMin: 25, WidthMax: 25},
+		{Number: 2, WidthMin: 13, WidthMax: 13},
+	})
+	rows := []table.Row{}
+
+	renderTable := func() {
+		title = fmt.Sprintf("%s %s", title, titleIcon)
+		tb.SetTitle(title)
+		tb.Render()
+	}
+
+	stat := p.Stat
+	rows = append(rows, table.Row{"Total tests", stat.Total})
+	rows = append(rows, table.Row{"Passed", stat.Passed})
+	rows = append(rows, table.Row{"Failed", stat.Failed})
+	rows = append(rows, table.Row{"Timeout", stat.Timeout})
+	rows = append(rows, table.Row{"Skipped", stat.Skipped})
+	titleIcon = iconsCollor[stat.Status]
+
+	if p.Name == plugin.PluginNameOpenShiftUpgrade || p.Name == plugin.PluginNameArtifactsCollector {
+		rows = append(rows, table.Row{"Result Job", stat.Status})
+		tb.AppendRows(rows)
+		renderTable()
+		return
+	}
+	for _, f := range p.Filters {
+		if f.Skipped {
+			continue
+		}
+		rows = append(rows, table.Row{fmt.Sprintf("Filter %s", f.ID), plugin.UtilsCalcPercStr(f.Failures, stat.Total)})
+	}
+	rows = append(rows, table.Row{"Failures (Priotity)", plugin.UtilsCalcPercStr(stat.FilterFailures, stat.Total)})
+
+	// TODO(mtulio): review suites provides better signal.
+	// The final results for non-kubernetes conformance will be hidden (pass|fail) for a while for those reasons:
+	// - OPCT was created to provide feeaback of conformance results, not a passing binary value. The numbers should be interpreted individually
+	// - Conformance results could have flakes or runtime failures which need to be investigated by executor
+	// - Force user/executor to review the results, and not only the summary.
+	// That behavior is aligned with BU: we expect kubernetes conformance passes in all providers, the reviewer
+	// must set this as a target in the review process.
+	// UPDATED(mtulio): OPCT is providing signals for conformance suites. The openshift-validated/conformance
+	// passing after filters means the baseline has common failures, which needs to be investigated in the future
+	// for non-providers - because there is a big chance to be related with the environment or platform-wide issue/bug.
+	// Leaving it commmented and providing a 'processed' result for openshift-conformance too.
+	// if p.Name != plugin.PluginNameKubernetesConformance {
+	// 	rows = append(rows, table.Row{"Result Job", stat.Status})
+	// 	tb.AppendRows(rows)
+	// 	renderTable()
+	// 	return
+	// }
+
+	// checking for runtime failures
+	runtimeFailed := false
+	if stat.Total == stat.Failed {
+		runtimeFailed = true
+	}
+
+	// rewrite the original status when pass on all filters and not failed on runtime
+	status := stat.Status
+	if (stat.FilterFailures == 0) && !runtimeFailed {
+		status = "passed"
+	}
+
+	rows = append(rows, table.Row{"Result - Job", stat.Status})
+	rows = append(rows, table.Row{"Result - Processed", status})
+	tb.AppendRows(rows)
+	titleIcon = iconsCollor[status]
+	if p.Name == plugin.PluginNameConformanceReplay && status != "passed" {
+		titleIcon = iconsBW["warn"]
+	}
+	renderTable()
+}
+
+// showErrorDetails show details of failres for each plugin.
+func showErrorDetails(w io.Writer, re *report.ReportData, verbose bool) error {
+	fmt.Fprintf(w, "\n==> Result details by conformance plugins: \n")
+
+	showErrorDetailPlugin(w, re.Provider.Plugins[plugin.PluginNameKubernetesConformance], verbose)
+	showErrorDetailPlugin(w, re.Provider.Plugins[plugin.PluginNameOpenShiftConformance], verbose)
+
+	return nil
+}
+
+// showErrorDetailPlugin Show failed e2e tests by filter, when verbose each filter will be shown.
+func showErrorDetailPlugin(w io.Writer, p *report.ReportPlugin, verbose bool) {
+	if p == nil {
+		errlog.LogError(errors.New("unable to get plugin"))
+		return
+	}
+	fmt.Fprintf(w, "==> %s - test failures:\n", p.Name)
+
+	// Plugin failures table - setup
+	st := table.StyleLight
+	st.Options.SeparateRows = true
+
+	// Build table utility
+	defaultHeaderRow := table.Row{"#Err", "#Flake", "%Flake", "State", "Test Name"}
+	buildTable := func(title string, header table.Row) table.Writer {
+		tb := table.NewWriter()
+		tb.SetOutputMirror(w)
+		tb.SetStyle(st)
+		tb.SetTitle(title)
+		tb.AppendHeader(header)
+		tb.SetColumnConfigs([]table.ColumnConfig{
+			{Number: 5, AlignHeader: tabletext.AlignCenter, WidthMax: 67},
+		})
+		return tb
+	}
+	populateTable := func(tb table.Writer, failures []*report.ReportTestFailure, skipFlake bool) {
+		for _, failure := range failures {
+			test, ok := p.Tests[failure.Name]
+			if !ok {
+				errlog.LogError(errors.New(fmt.Sprintf("unable to get test %s", failure.Name)))
+				continue
+			}
+			errCount := 0
+			if _, ok := test.ErrorCounters["total"]; ok {
+				errCount = test.ErrorCounters["total"]
+			}
+			if skipFlake {
+				baselineFreq := "--"
+				if test.Baseline != nil {
+					baselineFreq = test.Baseline.String()
+				}
+				history := "--"
+				if failure.History != nil {
+					history = failure.History.String()
+				}
+				tb.AppendRow(table.Row{errCount, baselineFreq, history, test.Name})
+				continue
+			}
+			if test.Flake == nil {
+				tb.AppendRow(table.Row{errCount, "--", "--", test.State, test.Name})
+				continue
+			}
+			tb.AppendRow(table.Row{errCount, test.Flake.CurrentFlakes, fmt.Sprintf("%.2f", test.Flake.CurrentFlakePerc), test.State, test.Name})
+		}
+		tb.Render()
+	}
+
+	// Table for Priority
+	if len(p.FailedFiltered) > 0 {
+		tb := table.NewWriter()
+		tb.SetOutputMirror(w)
+		tb.SetStyle(st)
+		tb.AppendHeader(table.Row{"#Err", "Baseline", "History", "Test Name"})
+		tb.AppendFooter(table.Row{"", "", "", p.TagsFiltered})
+		tb.SetColumnConfigs([]table.ColumnConfig{
+			{Number: 4, AlignHeader: tabletext.AlignCenter, WidthMax: 100},
+		})
+		title := fmt.Sprintf("==> %s \n%s ACTION REQUIRED: Failed tests to review", p.Name, iconsCollor["alert"])
+		tb.SetTitle(title)
+		populateTable(tb, p.FailedFiltered, true)
+	}
+
+	// Table for Flakes
+	if f := p.GetFilter(plugin.FilterNameFlaky); f != nil && len(f.ExcludedTests) > 0 {
+		title := fmt.Sprintf("\n==> %s\n Failed tests excluded in %q filter (%d)", p.Name, f.ID, len(f.ExcludedTests))
+		tb := buildTable(title, defaultHeaderRow)
+		populateTable(tb, f.ExcludedTests, false)
+	}
+
+	if verbose {
+		fmt.Fprintf(w, "\n Show failures by filter (verbose mode): %v\n", verbose)
+		skipFlake := false
+		titlePrefix := fmt.Sprintf("==> %s\n Failed tests excluded in", p.Name)
+		// Show tests removed by the other filters, from the last to the first in the pipeline.
+		for i := len(p.Filters) - 1; i >= 0; i-- {
+			f := p.Filters[i]
+			if f.ID == plugin.FilterNameFlaky || len(f.ExcludedTests) == 0 {
+				continue
+			}
+			title := fmt.Sprintf("%s %q filter (%d)", titlePrefix, f.ID, len(f.ExcludedTests))
+			tb := buildTable(title, defaultHeaderRow)
+			populateTable(tb, f.ExcludedTests, skipFlake)
+		}
+	}
+}
+
+// showChecks show the checks results / final report.
+func showChecks(w io.Writer, re *report.ReportData) error {
+	rowsFailures := []table.Row{}
+	rowsWarns := []table.Row{}
+	rowsPass := []table.Row{}
+	rowSkip := []table.Row{}
+	rowsWaived := []table.Row{}
+
+	fmt.Fprintf(w, "\n\n")
+	tb := table.NewWriter()
+	tb.SetOutputMirror(w)
+	tb.SetStyle(table.StyleLight)
+	tb.AppendHeader(table.Row{"ID", "#", "Result", "Check name", "Target", "Current"})
+	tb.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, AlignHeader: tabletext.AlignCenter},
+		{Number: 2, AlignHeader: tabletext.AlignCenter, Align: tabletext.AlignCenter},
+		{Number: 3, AlignHeader: tabletext.AlignCenter, Align: tabletext.AlignCenter},
+		{Number: 4, AlignHeader: tabletext.AlignCenter, AlignFooter: tabletext.AlignCenter},
+		{Number: 5, AlignHeader: tabletext.AlignCenter},
+		{Number: 6, AlignHeader: tabletext.AlignCenter},
+	})
+
+	allChecks := append([]*report.SLOOutput{}, re.Checks.Fail...)
+	allChecks = append(allChecks, re.Checks.Warn...)
+	allChecks = append(allChecks, re.Checks.Pass...)
+	allChecks = append(allChecks, re.Checks.Skip...)
+	allChecks = append(allChecks, re.Checks.Waived...)
+	for _, check := range re.Checks.Fail {
+		rowsFailures = append(rowsFailures, table.Row{
+			check.ID, iconsCollor[check.SLOResult], check.SLOResult, check.SLO, check.SLITarget, check.SLIActual,
+		})
+	}
+	for _, check := range re.Checks.Warn {
+		rowsWarns = append(rowsWarns, table.Row{
+			check.ID, iconsBW[check.SLOResult], check.SLOResult, check.SLO, check.SLITarget, check.SLIActual,
+		})
+	}
+	for _, check := range re.Checks.Pass {
+		rowsPass = append(rowsPass, table.Row{
+			check.ID, iconsBW[check.SLOResult], check.SLOResult, check.SLO, check.SLITarget, check.SLIActual,
+		})
+	}
+	for _, check := range re.Checks.Skip {
+		rowSkip = append(rowSkip, table.Row{
+			check.ID, iconsBW["pass"], check.SLOResult, check.SLO, check.SLITarget, check.SLIActual,
+		})
+	}
+
+	for _, check := range re.Checks.Waived {
+		name := check.SLO
+		if check.Waiver != nil {
+			name = fmt.Sprintf("%s\n(waived by %s until %s: %s)", check.SLO, check.Waiver.Approver, check.Waiver.Expires, check.Waiver.Justification)
+		}
+		rowsWaived = append(rowsWaived, table.Row{
+			check.ID, iconsBW[check.SLOResult], check.SLOResult, name, check.SLITarget, check.SLIActual,
+		})
+	}
+
+	if len(rowsFailures) > 0 {
+		tb.AppendRows(rowsFailures)
+		tb.AppendSeparator()
+	}
+	if len(rowsWaived) > 0 {
+		tb.AppendRows(rowsWaived)
+		tb.AppendSeparator()
+	}
+	if len(rowsWarns) > 0 {
+		tb.AppendRows(rowsWarns)
+		tb.AppendSeparator()
+	}
+	if len(rowsPass) > 0 {
+		tb.AppendRows(rowsPass)
+		tb.AppendSeparator()
+	}
+	if len(rowSkip) > 0 {
+		tb.AppendRows(rowSkip)
+	}
+
+	total := len(allChecks)
+	summary := fmt.Sprintf("Total: %d, Failed: %d (%.2f%%), Warn: %d (%.2f%%), Pass: %d (%.2f%%), Skip: %d (%.2f%%)", total,
+		len(re.Checks.Fail), (float64(len(re.Checks.Fail))/float64(total))*100,
+		len(re.Checks.Warn), (float64(len(re.Checks.Warn))/float64(total))*100,
+		len(re.Checks.Pass), (float64(len(re.Checks.Pass))/float64(total))*100,
+		len(re.Checks.Skip), (float64(len(re.Checks.Skip))/float64(total))*100,
+	)
+	if len(re.Checks.Waived) > 0 {
+		summary = fmt.Sprintf("%s, Waived: %d (%.2f%%)", summary,
+			len(re.Checks.Waived), (float64(len(re.Checks.Waived))/float64(total))*100)
+	}
+	tb.AppendFooter(table.Row{"", "", "", summary, "", ""})
+
+	title := "Validation checks / Results"
+	if re.Checks.Profile != "" {
+		title = fmt.Sprintf("%s (profile: %s)", title, re.Checks.Profile)
+	}
+	// Create a alert message when there are check failures.
+	if len(rowsFailures) > 0 {
+		alert := fmt.Sprintf(
+			"\t %s %s IMMEDIATE ACTION: %d Check(s) failed. Review it individually, fix and collect new results %s %s",
+			iconsCollor["alert"], iconsCollor["alert"], len(re.Checks.Fail), iconsCollor["alert"], iconsCollor["alert"])
+		title = fmt.Sprintf("%s\n%s", title, alert)
+	}
+	tb.SetTitle(title)
+	tb.Render()
+
+	return nil
+}
@@ -1,3 +1,21 @@
 package view
 
-// TODO: implement the HTML view for report.
+import (
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+// HTML renders the report as the web UI: the report data (opct-report.json)
+// and the frontend pages rendered from the templates.
+type HTML struct {
+	saveTo string
+}
+
+// NewHTML returns the HTML view saving the report to the directory.
+func NewHTML(saveTo string) *HTML {
+	return &HTML{saveTo: saveTo}
+}
+
+// Render saves the report data and the frontend pages to the directory.
+func (v *HTML) Render(re *report.ReportData) error {
+	return re.SaveResults(v.saveTo)
+}
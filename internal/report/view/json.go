@@ -0,0 +1,28 @@
+package view
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+// JSON renders the report data as JSON, the same format of opct-report.json.
+type JSON struct {
+	w io.Writer
+}
+
+// NewJSON returns the JSON view writing to w.
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{w: w}
+}
+
+// Render writes the report data as JSON.
+func (v *JSON) Render(re *report.ReportData) error {
+	data, err := re.ShowJSON()
+	if err != nil {
+		return fmt.Errorf("unable to encode the report to JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(v.w, data)
+	return err
+}
@@ -0,0 +1,280 @@
+package view
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+const (
+	// MarkdownTopFailures is the default number of failures to review shown by plugin.
+	MarkdownTopFailures = 10
+
+	// MarkdownMaxOutput is the maximum length of the failure output in the details,
+	// keeping the report below the comment limits of GitHub and Jira.
+	MarkdownMaxOutput = 2000
+)
+
+// Markdown renders the report summary as Markdown, to be pasted on support cases,
+// issues and pull requests. Long sections are collapsible.
+type Markdown struct {
+	w           io.Writer
+	topFailures int
+}
+
+// NewMarkdown returns the Markdown view writing to w.
+func NewMarkdown(w io.Writer) *Markdown {
+	return &Markdown{w: w, topFailures: MarkdownTopFailures}
+}
+
+// mdEscape escapes the characters breaking the Markdown tables.
+func mdEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+// mdTruncate limits the text to size characters (runes), keeping the beginning of the text.
+func mdTruncate(s string, size int) string {
+	runes := []rune(s)
+	if len(runes) <= size {
+		return s
+	}
+	return fmt.Sprintf("%s\n... (truncated, %d characters omitted)", string(runes[:size]), len(runes)-size)
+}
+
+// mdCodeBlock returns the fenced code block of the text, the fence is longer than
+// the backtick sequences of the text, preventing the text to close the block.
+func mdCodeBlock(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fmt.Sprintf("%s\n%s\n%s", fence, s, fence)
+}
+
+// mdLink returns the Markdown link, or the text when the URL is empty.
+func mdLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+// Render writes the cluster information, the plugin summary, the checks, the failures
+// to review and the etcd latency summary.
+func (v *Markdown) Render(re *report.ReportData) error {
+	if re.Provider == nil {
+		return fmt.Errorf("provider results not found in the report")
+	}
+	fmt.Fprintf(v.w, "# OPCT Report\n\n")
+	if re.Summary != nil && re.Summary.Tests != nil && re.Summary.Tests.Archive != "" {
+		fmt.Fprintf(v.w, "Archive: `%s`\n\n", re.Summary.Tests.Archive)
+	}
+	v.renderCluster(re.Provider)
+	v.renderPlugins(re.Provider)
+	v.renderChecks(re.Checks)
+	v.renderFailures(re.Provider)
+	v.renderEtcd(re.Provider)
+	return nil
+}
+
+func (v *Markdown) renderCluster(rs *report.ReportResult) {
+	if rs.Version == nil || rs.Infra == nil {
+		return
+	}
+	fmt.Fprintf(v.w, "## Cluster\n\n| | |\n| -- | -- |\n")
+	row := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(v.w, "| %s | %s |\n", name, mdEscape(value))
+		}
+	}
+	if ocp := rs.Version.OpenShift; ocp != nil {
+		row("OpenShift", ocp.Desired)
+		row("Channel", ocp.Channel)
+		row("Cluster ID", ocp.ClusterID)
+		row("Cluster Status", ocp.OverallStatus)
+	}
+	row("Kubernetes", rs.Version.Kubernetes)
+	row("OPCT", rs.Version.OPCTClient)
+	platform := rs.Infra.PlatformType
+	if rs.Infra.PlatformName != "" && rs.Infra.PlatformName != rs.Infra.PlatformType {
+		platform = fmt.Sprintf("%s (%s)", platform, rs.Infra.PlatformName)
+	}
+	row("PlatformType", platform)
+	row("Topology", rs.Infra.Topology)
+	row("ControlPlaneTopology", rs.Infra.ControlPlaneTopology)
+	row("NetworkType", rs.Infra.NetworkType)
+	if co := rs.ClusterOperators; co != nil {
+		row("Cluster Operators [A/P/D]", fmt.Sprintf("%d/%d/%d", co.CountAvailable, co.CountProgressing, co.CountDegraded))
+	}
+	if ch := rs.ClusterHealth; ch != nil && ch.NodeHealthTotal > 0 {
+		row("Node health", fmt.Sprintf("%d/%d (%.2f%%)", ch.NodeHealthy, ch.NodeHealthTotal, ch.NodeHealthPerc))
+	}
+	if ch := rs.ClusterHealth; ch != nil && ch.PodHealthTotal > 0 {
+		row("Pods health", fmt.Sprintf("%d/%d (%.2f%%)", ch.PodHealthy, ch.PodHealthTotal, ch.PodHealthPerc))
+	}
+	fmt.Fprintln(v.w)
+}
+
+func (v *Markdown) renderPlugins(rs *report.ReportResult) {
+	fmt.Fprintf(v.w, "## Plugins\n\n")
+	fmt.Fprintf(v.w, "| Plugin | Status | Total | Passed | Failed | Skipped | Timeout | Failures to review |\n")
+	fmt.Fprintf(v.w, "| -- | -- | -- | -- | -- | -- | -- | -- |\n")
+	plugins := rs.GetPlugins()
+	sort.Strings(plugins)
+	for _, name := range plugins {
+		p := rs.Plugins[name]
+		if p.Stat == nil {
+			continue
+		}
+		fmt.Fprintf(v.w, "| %s | %s | %d | %d | %d | %d | %d | %d |\n", p.Name, p.Stat.Status,
+			p.Stat.Total, p.Stat.Passed, p.Stat.Failed, p.Stat.Skipped, p.Stat.Timeout, len(p.FailedFiltered))
+	}
+	fmt.Fprintln(v.w)
+}
+
+func (v *Markdown) renderChecks(checks *report.ReportChecks) {
+	if checks == nil {
+		return
+	}
+	title := "## Checks"
+	if checks.Profile != "" {
+		title = fmt.Sprintf("%s (profile: %s)", title, checks.Profile)
+	}
+	fmt.Fprintf(v.w, "%s\n\n", title)
+	total := len(checks.Fail) + len(checks.Waived) + len(checks.Warn) + len(checks.Pass) + len(checks.Skip)
+	fmt.Fprintf(v.w, "Total: %d, Failed: %d, Waived: %d, Warn: %d, Pass: %d, Skip: %d\n\n", total,
+		len(checks.Fail), len(checks.Waived), len(checks.Warn), len(checks.Pass), len(checks.Skip))
+
+	for _, group := range []struct {
+		title     string
+		outputs   []*report.SLOOutput
+		collapsed bool
+	}{
+		{fmt.Sprintf("%s Failed", iconsCollor["fail"]), checks.Fail, false},
+		{fmt.Sprintf("%s Waived", iconsCollor["waived"]), checks.Waived, false},
+		{fmt.Sprintf("%s Warn", iconsBW["warn"]), checks.Warn, false},
+		{fmt.Sprintf("%s Pass", iconsCollor["pass"]), checks.Pass, true},
+		{"Skip", checks.Skip, true},
+	} {
+		if len(group.outputs) == 0 {
+			continue
+		}
+		title := fmt.Sprintf("%s (%d)", group.title, len(group.outputs))
+		if group.collapsed {
+			fmt.Fprintf(v.w, "<details>\n<summary>%s</summary>\n\n", title)
+		} else {
+			fmt.Fprintf(v.w, "### %s\n\n", title)
+		}
+		fmt.Fprintf(v.w, "| ID | Check | Target | Current | Message |\n| -- | -- | -- | -- | -- |\n")
+		for _, c := range group.outputs {
+			msg := c.Message
+			if c.Waiver != nil {
+				msg = fmt.Sprintf("waived by %s until %s: %s", c.Waiver.Approver, c.Waiver.Expires, c.Waiver.Justification)
+			}
+			fmt.Fprintf(v.w, "| %s | %s | %s | %s | %s |\n", mdLink(c.ID, c.Documentation), mdEscape(c.SLO),
+				mdEscape(c.SLITarget), mdEscape(c.SLIActual), mdEscape(msg))
+		}
+		if group.collapsed {
+			fmt.Fprintf(v.w, "\n</details>\n")
+		}
+		fmt.Fprintln(v.w)
+	}
+}
+
+func (v *Markdown) renderFailures(rs *report.ReportResult) {
+	plugins := rs.GetPlugins()
+	sort.Strings(plugins)
+	header := false
+	for _, name := range plugins {
+		p := rs.Plugins[name]
+		if len(p.FailedFiltered) == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintf(v.w, "## Failures to review\n\n")
+			header = true
+		}
+		failures := p.FailedFiltered
+		top := fmt.Sprintf("%d", len(failures))
+		if len(failures) > v.topFailures {
+			failures = failures[:v.topFailures]
+			top = fmt.Sprintf("top %d of %d", v.topFailures, len(p.FailedFiltered))
+		}
+		fmt.Fprintf(v.w, "### %s (%s)\n\n", p.Name, top)
+		if p.TagsFiltered != "" {
+			fmt.Fprintf(v.w, "Tags: `%s`\n\n", p.TagsFiltered)
+		}
+		fmt.Fprintf(v.w, "| #Err | Flake %% | Test |\n| -- | -- | -- |\n")
+		for _, f := range failures {
+			flake := "--"
+			if f.FlakeCount > 0 {
+				flake = fmt.Sprintf("%.2f", f.FlakePerc)
+			}
+			fmt.Fprintf(v.w, "| %d | %s | %s |\n", f.ErrorsCount, flake, mdLink(mdEscape(f.Name), f.Documentation))
+		}
+		fmt.Fprintln(v.w)
+
+		// Failure output is available only when the report is processed from the archive.
+		for _, f := range failures {
+			test, ok := p.Tests[f.Name]
+			if !ok || (test.Failure == "" && test.SystemOut == "") {
+				continue
+			}
+			fmt.Fprintf(v.w, "<details>\n<summary>%s</summary>\n\n", html.EscapeString(f.Name))
+			if test.Failure != "" {
+				fmt.Fprintf(v.w, "Failure:\n\n%s\n\n", mdCodeBlock(mdTruncate(test.Failure, MarkdownMaxOutput)))
+			}
+			if test.SystemOut != "" {
+				fmt.Fprintf(v.w, "Output:\n\n%s\n\n", mdCodeBlock(mdTruncate(test.SystemOut, MarkdownMaxOutput)))
+			}
+			fmt.Fprintf(v.w, "</details>\n\n")
+		}
+	}
+}
+
+func (v *Markdown) renderEtcd(rs *report.ReportResult) {
+	if rs.MustGatherInfo == nil || rs.MustGatherInfo.ErrorEtcdLogs == nil {
+		return
+	}
+	stat := rs.MustGatherInfo.ErrorEtcdLogs.FilterRequestSlowAll[mustgather.BucketRangeNameAll]
+	if stat == nil {
+		return
+	}
+	fmt.Fprintf(v.w, "## etcd slow requests\n\n")
+	fmt.Fprintf(v.w, "| Requests | >500ms | Mean | p90 | p99 | p99.9 | Max |\n| -- | -- | -- | -- | -- | -- | -- |\n")
+	fmt.Fprintf(v.w, "| %d | %s | %s | %s | %s | %s | %s |\n\n", stat.RequestCount, stat.Higher500ms,
+		stat.StatMean, stat.StatPerc90, stat.StatPerc99, stat.StatPerc999, stat.StatMax)
+
+	buckets := make([]string, 0, len(stat.Buckets))
+	for name := range stat.Buckets {
+		buckets = append(buckets, name)
+	}
+	if len(buckets) == 0 {
+		return
+	}
+	// buckets are named by range, sorted by the lower bound. Example: 200-300
+	lower := func(name string) int {
+		n, _ := strconv.Atoi(strings.Split(name, "-")[0])
+		return n
+	}
+	sort.Slice(buckets, func(i, j int) bool { return lower(buckets[i]) < lower(buckets[j]) })
+	fmt.Fprintf(v.w, "<details>\n<summary>Requests by latency bucket (ms)</summary>\n\n| Bucket | Requests |\n| -- | -- |\n")
+	for _, name := range buckets {
+		fmt.Fprintf(v.w, "| %s | %s |\n", name, stat.Buckets[name])
+	}
+	fmt.Fprintf(v.w, "\n</details>\n\n")
+}
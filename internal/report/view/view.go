@@ -0,0 +1,56 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+// Output formats of the report (opct report -o).
+const (
+	FormatCLI      = "cli"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Formats are the supported output formats of the report.
+var Formats = []string{FormatCLI, FormatHTML, FormatJSON, FormatMarkdown}
+
+// View renders the report data. Views should not have any logic, the report
+// data must be populated before rendering.
+type View interface {
+	Render(re *report.ReportData) error
+}
+
+// Options are the options of the views.
+type Options struct {
+	// Writer is the output of the CLI, JSON and Markdown views.
+	Writer io.Writer
+
+	// Verbose shows the failures excluded by each filter (CLI).
+	Verbose bool
+
+	// SaveTo is the directory to save the HTML report.
+	SaveTo string
+}
+
+// New returns the view of the output format.
+func New(format string, opts *Options) (View, error) {
+	switch format {
+	case FormatCLI:
+		return NewCLI(opts.Writer, opts.Verbose), nil
+	case FormatHTML:
+		if opts.SaveTo == "" {
+			return nil, fmt.Errorf("output format %s requires the directory to save the report", FormatHTML)
+		}
+		return NewHTML(opts.SaveTo), nil
+	case FormatJSON:
+		return NewJSON(opts.Writer), nil
+	case FormatMarkdown:
+		return NewMarkdown(opts.Writer), nil
+	}
+	return nil, fmt.Errorf("invalid output format %q, valid values: %s", format, strings.Join(Formats, ", "))
+}
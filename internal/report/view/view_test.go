@@ -0,0 +1,131 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/mustgather"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+// newTestReport returns the report data with the fields required by the views.
+func newTestReport() *report.ReportData {
+	newPlugin := func(name string, failures ...string) *report.ReportPlugin {
+		p := &report.ReportPlugin{
+			ID:    name,
+			Name:  name,
+			Stat:  &report.ReportPluginStat{Status: "failed", Total: 100, Passed: 100 - int64(len(failures)), Failed: int64(len(failures)), FilterFailures: int64(len(failures))},
+			Suite: &summary.OpenshiftTestsSuite{Count: 100},
+			Tests: map[string]*plugin.TestItem{},
+		}
+		for _, f := range failures {
+			p.Tests[f] = &plugin.TestItem{Name: f, Failure: "failure of " + f, SystemOut: "output of " + f}
+			p.FailedFiltered = append(p.FailedFiltered, &report.ReportTestFailure{Name: f, ErrorsCount: 2})
+		}
+		return p
+	}
+	return &report.ReportData{
+		Summary: &report.ReportSummary{Tests: &report.ReportSummaryTests{Archive: "/tmp/archive.tar.gz"}},
+		Provider: &report.ReportResult{
+			Version: &report.ReportVersion{
+				OpenShift:  &summary.SummaryClusterVersionOutput{Desired: "4.16.1", OverallStatus: "Available"},
+				Kubernetes: "v1.29.5",
+			},
+			Infra:            &report.ReportInfra{Name: "mycluster", PlatformType: "External", PlatformName: "oci", Topology: "HighlyAvailable"},
+			ClusterOperators: &report.ReportClusterOperators{},
+			ClusterHealth:    &report.ReportClusterHealth{},
+			Plugins: map[string]*report.ReportPlugin{
+				plugin.PluginNameKubernetesConformance: newPlugin(plugin.PluginNameKubernetesConformance),
+				plugin.PluginNameOpenShiftConformance:  newPlugin(plugin.PluginNameOpenShiftConformance, "[sig-network] test | pipe"),
+				plugin.PluginNameOpenShiftUpgrade:      newPlugin(plugin.PluginNameOpenShiftUpgrade),
+			},
+		},
+		Checks: &report.ReportChecks{
+			Fail: []*report.SLOOutput{{ID: "OPCT-004", SLO: "OpenShift Conformance must pass", SLOResult: "fail", SLITarget: "pass", SLIActual: "fail"}},
+			Pass: []*report.SLOOutput{{ID: "OPCT-001", SLO: "Kubernetes Conformance must pass", SLOResult: "pass", SLITarget: "pass", SLIActual: "pass"}},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	buf := &bytes.Buffer{}
+	for _, format := range []string{FormatCLI, FormatJSON, FormatMarkdown} {
+		_, err := New(format, &Options{Writer: buf})
+		assert.NoError(t, err, format)
+	}
+	_, err := New(FormatHTML, &Options{Writer: buf})
+	assert.Error(t, err, "html requires the directory")
+	v, err := New(FormatHTML, &Options{SaveTo: t.TempDir()})
+	assert.NoError(t, err)
+	assert.IsType(t, &HTML{}, v)
+	_, err = New("yaml", &Options{Writer: buf})
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	re := newTestReport()
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, NewCLI(buf, false).Render(re))
+	assert.Contains(t, buf.String(), "OPCT Summary")
+	assert.Contains(t, buf.String(), "ACTION REQUIRED")
+	assert.Contains(t, buf.String(), "Validation checks / Results")
+
+	buf.Reset()
+	assert.NoError(t, NewJSON(buf).Render(re))
+	decoded := &report.ReportData{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, "4.16.1", decoded.Provider.Version.OpenShift.Desired)
+
+}
+
+func TestMarkdownRender(t *testing.T) {
+	re := newTestReport()
+	re.Checks.Fail[0].Documentation = "https://example.com/checks#OPCT-004"
+	ocp := re.Provider.Plugins[plugin.PluginNameOpenShiftConformance]
+	ocp.TagsFiltered = "[sig-network]=1"
+	ocp.Tests["[sig-network] test | pipe"].SystemOut = strings.Repeat("x", MarkdownMaxOutput+10)
+	re.Provider.MustGatherInfo = &mustgather.MustGather{
+		ErrorEtcdLogs: &mustgather.ErrorEtcdLogs{
+			FilterRequestSlowAll: map[string]*mustgather.BucketFilterStat{
+				"all": {RequestCount: 30, StatPerc99: "750.000 (ms)", Buckets: map[string]string{"1000-inf": "10", "200-300": "20"}},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, NewMarkdown(buf).Render(re))
+	md := buf.String()
+	assert.Contains(t, md, "| OpenShift | 4.16.1 |")
+	assert.Contains(t, md, "| PlatformType | External (oci) |")
+	assert.Contains(t, md, "| 20-openshift-conformance-validated | failed | 100 | 99 | 1 | 0 | 0 | 1 |")
+	assert.Contains(t, md, "| [OPCT-004](https://example.com/checks#OPCT-004) | OpenShift Conformance must pass | pass | fail |  |")
+	assert.Contains(t, md, "<summary>✅ Pass (1)</summary>")
+	assert.Contains(t, md, "Tags: `[sig-network]=1`")
+	assert.Contains(t, md, "| 2 | -- | [sig-network] test \\| pipe |")
+	assert.Contains(t, md, "Failure:\n\n```\nfailure of [sig-network] test | pipe\n```")
+	assert.Contains(t, md, "(truncated, 10 characters omitted)")
+	assert.Contains(t, md, "| 30 |  |  |  | 750.000 (ms) |  |  |")
+	assert.Less(t, strings.Index(md, "| 200-300 | 20 |"), strings.Index(md, "| 1000-inf | 10 |"))
+
+	// multi-byte output is truncated on a rune boundary, fences in the output don't close the block.
+	assert.Equal(t, "ééé\n... (truncated, 2 characters omitted)", mdTruncate("ééééé", 3))
+	assert.Equal(t, "```\nok\n```", mdCodeBlock("ok"))
+	assert.Equal(t, "````\n```go\nx\n```\n````", mdCodeBlock("```go\nx\n```"))
+
+	// failures are limited to the top failures by plugin.
+	for i := 0; i < MarkdownTopFailures+5; i++ {
+		ocp.FailedFiltered = append(ocp.FailedFiltered, &report.ReportTestFailure{Name: fmt.Sprintf("test %d", i)})
+	}
+	buf.Reset()
+	assert.NoError(t, NewMarkdown(buf).Render(re))
+	assert.Contains(t, buf.String(), "### 20-openshift-conformance-validated (top 10 of 16)")
+	assert.NotContains(t, buf.String(), "| test 10 |")
+}
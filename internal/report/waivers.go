@@ -0,0 +1,153 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// CheckWaiver accepts the failure of a check, moving it to the waived results.
+type CheckWaiver struct {
+	// ID is the check ID waived.
+	ID string `yaml:"id" json:"id"`
+
+	// Justification is the reason the failure is accepted.
+	Justification string `yaml:"justification" json:"justification"`
+
+	// Approver is the person, or team, accepting the failure.
+	Approver string `yaml:"approver" json:"approver"`
+
+	// Expires is the date (YYYY-MM-DD or RFC3339) the waiver stops matching the check.
+	Expires string `yaml:"expires" json:"expires"`
+
+	// Clusters limits the waiver to the clusters, by cluster ID or infrastructure name.
+	Clusters []string `yaml:"clusters,omitempty" json:"clusters,omitempty"`
+
+	// PlatformTypes limits the waiver to the infrastructure platform types. Example: AWS, External, None.
+	PlatformTypes []string `yaml:"platformTypes,omitempty" json:"platformTypes,omitempty"`
+
+	expiresAt time.Time
+}
+
+// CheckWaiversFile is the file format of the check waivers file (--check-waivers).
+type CheckWaiversFile struct {
+	Waivers []*CheckWaiver `yaml:"waivers"`
+}
+
+// LoadCheckWaiversFromFile reads and validates the check waivers file.
+func LoadCheckWaiversFromFile(path string) ([]*CheckWaiver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read check waivers file: %w", err)
+	}
+	cwf := CheckWaiversFile{}
+	if err := yaml.UnmarshalStrict(data, &cwf); err != nil {
+		return nil, fmt.Errorf("unable to parse check waivers file %s: %w", path, err)
+	}
+	for idx, w := range cwf.Waivers {
+		if err := w.validate(); err != nil {
+			return nil, fmt.Errorf("invalid check waiver entry #%d in %s: %w", idx, path, err)
+		}
+	}
+	return cwf.Waivers, nil
+}
+
+// SetCheckWaivers sets the waivers applied to the failed checks by Populate.
+func (re *ReportData) SetCheckWaivers(waivers []*CheckWaiver) {
+	re.checkWaivers = waivers
+}
+
+// validate checks the required fields, parsing the expiry date.
+func (w *CheckWaiver) validate() error {
+	if w.ID == "" || w.ID == CheckIdEmptyValue {
+		return fmt.Errorf("'id' must be set to a check ID")
+	}
+	if w.Justification == "" {
+		return fmt.Errorf("'justification' must be set")
+	}
+	if w.Approver == "" {
+		return fmt.Errorf("'approver' must be set")
+	}
+	if w.Expires == "" {
+		return fmt.Errorf("'expires' must be set")
+	}
+	// date-only entries are valid until the end of the day.
+	t, err := time.Parse("2006-01-02", w.Expires)
+	if err == nil {
+		t = t.Add(24 * time.Hour)
+	} else {
+		t, err = time.Parse(time.RFC3339, w.Expires)
+		if err != nil {
+			return fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD or RFC3339", w.Expires)
+		}
+	}
+	w.expiresAt = t
+	return nil
+}
+
+// IsExpired checks if the waiver has expired in a given time.
+func (w *CheckWaiver) IsExpired(now time.Time) bool {
+	return !now.Before(w.expiresAt)
+}
+
+// InScope checks if the waiver applies to the cluster (ID or infrastructure name)
+// and the platform type. Empty scope attributes match everything.
+func (w *CheckWaiver) InScope(clusterID, infraName, platformType string) bool {
+	if len(w.Clusters) > 0 {
+		found := false
+		for _, c := range w.Clusters {
+			if c != "" && (c == clusterID || c == infraName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(w.PlatformTypes) > 0 {
+		found := false
+		for _, p := range w.PlatformTypes {
+			if strings.EqualFold(p, platformType) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SetWaivers sets the waivers in scope of the report cluster, applied to the
+// failed checks when the summary runs. Expired waivers are reported and ignored.
+func (csum *CheckSummary) SetWaivers(re *ReportData, waivers []*CheckWaiver) {
+	var clusterID, infraName, platformType string
+	if re.Provider != nil {
+		if re.Provider.Version != nil && re.Provider.Version.OpenShift != nil {
+			clusterID = re.Provider.Version.OpenShift.ClusterID
+		}
+		if re.Provider.Infra != nil {
+			infraName = re.Provider.Infra.Name
+			platformType = re.Provider.Infra.PlatformType
+		}
+	}
+	now := time.Now()
+	csum.waivers = map[string]*CheckWaiver{}
+	for _, w := range waivers {
+		if !w.InScope(clusterID, infraName, platformType) {
+			log.Debugf("Check waiver %s is not in scope of the cluster, ignoring", w.ID)
+			continue
+		}
+		if w.IsExpired(now) {
+			log.Warnf("Check waiver %s approved by %s has expired (%s), ignoring", w.ID, w.Approver, w.Expires)
+			continue
+		}
+		csum.waivers[w.ID] = w
+	}
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+)
+
+func TestLoadCheckWaiversFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "waivers.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	waivers, err := LoadCheckWaiversFromFile(write(`waivers:
+- id: OPCT-030
+  justification: Single zone region, accepted by the partner program.
+  approver: jdoe
+  expires: "2999-12-31"
+  clusters: ["1234-abcd"]
+  platformTypes: ["External"]
+`))
+	assert.NoError(t, err)
+	assert.Len(t, waivers, 1)
+	assert.False(t, waivers[0].IsExpired(time.Now()))
+	assert.True(t, waivers[0].InScope("1234-abcd", "", "external"))
+	assert.True(t, waivers[0].InScope("", "1234-abcd", "External"))
+	assert.False(t, waivers[0].InScope("5678-efgh", "mycluster", "External"))
+	assert.False(t, waivers[0].InScope("1234-abcd", "", "AWS"))
+
+	invalid := []struct {
+		name    string
+		content string
+	}{
+		{"missing id", "waivers:\n- justification: x\n  approver: y\n  expires: '2999-12-31'\n"},
+		{"check without id", "waivers:\n- id: '--'\n  justification: x\n  approver: y\n  expires: '2999-12-31'\n"},
+		{"missing justification", "waivers:\n- id: OPCT-030\n  approver: y\n  expires: '2999-12-31'\n"},
+		{"missing approver", "waivers:\n- id: OPCT-030\n  justification: x\n  expires: '2999-12-31'\n"},
+		{"missing expiry", "waivers:\n- id: OPCT-030\n  justification: x\n  approver: y\n"},
+		{"invalid expiry", "waivers:\n- id: OPCT-030\n  justification: x\n  approver: y\n  expires: tomorrow\n"},
+		{"unknown field", "waivers:\n- id: OPCT-030\n  reason: x\n"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadCheckWaiversFromFile(write(tc.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheckSummaryWaivers(t *testing.T) {
+	re := newRulesTestReport()
+	re.Provider.Version.OpenShift.ClusterID = "1234-abcd"
+	re.Provider.Nodes = []*summary.Node{{ControlPlane: true}, {ControlPlane: true}, {ControlPlane: true}}
+
+	waivers := []*CheckWaiver{
+		{ID: "OPCT-030", Justification: "single zone", Approver: "jdoe", Expires: "2999-12-31", Clusters: []string{"1234-abcd"}},
+		{ID: "OPCT-021", Justification: "expired", Approver: "jdoe", Expires: "2000-01-01"},
+		{ID: "OPCT-002", Justification: "other cluster", Approver: "jdoe", Expires: "2999-12-31", Clusters: []string{"5678-efgh"}},
+		// passed checks are not waived
+		{ID: "OPCT-020", Justification: "not failing", Approver: "jdoe", Expires: "2999-12-31"},
+	}
+	for _, w := range waivers {
+		assert.NoError(t, w.validate())
+	}
+
+	cs := NewCheckSummary(re)
+	cs.SetWaivers(re, waivers)
+	assert.NoError(t, cs.Run())
+	pass, fail, _, _, waived := cs.GetCheckResults()
+
+	assert.Len(t, waived, 1)
+	assert.Equal(t, "OPCT-030", waived[0].ID)
+	assert.Equal(t, string(CheckResultNameWaived), waived[0].SLOResult)
+	assert.Equal(t, "Zones==0", waived[0].SLIActual)
+	assert.Equal(t, waivers[0], waived[0].Waiver)
+
+	ids := func(outputs []*SLOOutput) []string {
+		res := []string{}
+		for _, o := range outputs {
+			res = append(res, o.ID)
+		}
+		return res
+	}
+	assert.Contains(t, ids(fail), "OPCT-021")
+	assert.Contains(t, ids(fail), "OPCT-002")
+	assert.NotContains(t, ids(fail), "OPCT-030")
+	assert.Contains(t, ids(pass), "OPCT-020")
+}
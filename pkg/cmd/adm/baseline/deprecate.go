@@ -0,0 +1,38 @@
+package baseline
+
+import (
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+	"github.com/spf13/cobra"
+)
+
+type baselineDeprecateInput struct {
+	reason string
+	unset  bool
+	dryRun bool
+}
+
+var baselineDeprecateArgs baselineDeprecateInput
+var baselineDeprecateCmd = &cobra.Command{
+	Use:     "deprecate <baseline name>",
+	Example: "opct adm baseline deprecate 4.15_None_20240101000000 --reason 'invalid infrastructure'",
+	Short:   "(Administrative usage) Deprecate a baseline result.",
+	Long: `(Administrative usage) Deprecate a baseline result.
+	Deprecated results are kept in the store and in the index, but are never picked by the
+	indexer as the latest result of the release and platform type.`,
+	Args: cobra.ExactArgs(1),
+	Run:  baselineDeprecateCmdRun,
+}
+
+func init() {
+	baselineDeprecateCmd.Flags().StringVar(&baselineDeprecateArgs.reason, "reason", "true", "Reason of the deprecation.")
+	baselineDeprecateCmd.Flags().BoolVar(&baselineDeprecateArgs.unset, "unset", false, "Remove the deprecation of the result.")
+	baselineDeprecateCmd.Flags().BoolVar(&baselineDeprecateArgs.dryRun, "dry-run", false, "Print the planned change without changing the store.")
+}
+
+func baselineDeprecateCmdRun(cmd *cobra.Command, args []string) {
+	value := baselineDeprecateArgs.reason
+	if baselineDeprecateArgs.unset {
+		value = ""
+	}
+	setLifecycleTag(args[0], reb.TagDeprecated, value, baselineDeprecateArgs.dryRun)
+}
@@ -0,0 +1,80 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type baselineDiffInput struct {
+	output string
+}
+
+var baselineDiffArgs baselineDiffInput
+var baselineDiffCmd = &cobra.Command{
+	Use:     "diff <baseline name A> <baseline name B>",
+	Example: "opct adm baseline diff 4.15_AWS_20240101000000 4.15_AWS_latest -o markdown",
+	Short:   "Compare two baseline results.",
+	Long: `Compare two baseline results, from A (before) to B (after).
+	The comparison shows, by plugin, the new and resolved failures (after the filter pipeline),
+	the change in the counters, and the checks which result changed.
+	Baseline names are listed by 'opct adm baseline list', the latest result of a release and
+	platform type can be referenced by the name {release}_{platformType}_latest.`,
+	Args: cobra.ExactArgs(2),
+	Run:  baselineDiffCmdRun,
+}
+
+func init() {
+	baselineDiffCmd.Flags().StringVarP(&baselineDiffArgs.output, "output", "o", report.DiffFormatTable,
+		fmt.Sprintf("Output format. One of: %s, %s, %s", report.DiffFormatTable, report.DiffFormatJSON, report.DiffFormatMarkdown))
+}
+
+// getSummaryByName reads the baseline summary by name.
+func getSummaryByName(rb *reb.BaselineConfig, name string) (*report.ReportData, error) {
+	data, err := rb.GetSummaryByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", name, err)
+	}
+	re := &report.ReportData{}
+	if err := json.Unmarshal(data, re); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal baseline %s: %w", name, err)
+	}
+	return re, nil
+}
+
+func baselineDiffCmdRun(cmd *cobra.Command, args []string) {
+	switch baselineDiffArgs.output {
+	case report.DiffFormatTable, report.DiffFormatJSON, report.DiffFormatMarkdown:
+	default:
+		log.Fatalf("invalid output format %q, valid values: %s, %s, %s", baselineDiffArgs.output,
+			report.DiffFormatTable, report.DiffFormatJSON, report.DiffFormatMarkdown)
+	}
+	rb := newBaselineConfig(reb.DefaultStoreURL())
+	reA, err := getSummaryByName(rb, args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	reB, err := getSummaryByName(rb, args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diff := report.NewReportDiff(args[0], reA, args[1], reB)
+	switch baselineDiffArgs.output {
+	case report.DiffFormatJSON:
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to encode the diff to JSON: %v", err)
+		}
+		fmt.Println(string(data))
+	case report.DiffFormatMarkdown:
+		diff.RenderMarkdown(os.Stdout)
+	default:
+		diff.RenderTable(os.Stdout)
+	}
+}
@@ -52,15 +52,14 @@ func baselineGetCmdRun(cmd *cobra.Command, args []string) {
 
 	var err error
 	var data []byte
-	rb := reb.NewBaselineReportSummary()
+	rb := newBaselineConfig(reb.DefaultStoreURL())
 	if baselineGetArgs.name != "" {
 		log.Infof("Getting baseline result by name: %s", baselineGetArgs.name)
 		data, err = rb.GetSummaryByName(baselineGetArgs.name)
 	} else {
 		log.Infof("Getting latest baseline result by release and platform: %s/%s", baselineGetArgs.release, baselineGetArgs.platform)
 		if err := rb.GetLatestSummaryByPlatform(baselineGetArgs.release, baselineGetArgs.platform); err != nil {
-			log.Errorf("error getting latest summary by platform: %v", err)
-			return
+			log.Fatalf("error getting latest summary by platform: %v", err)
 		}
 		data = rb.GetBuffer().GetRawData()
 	}
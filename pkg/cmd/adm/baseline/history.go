@@ -0,0 +1,103 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+
+	table "github.com/jedib0t/go-pretty/v6/table"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type baselineHistoryInput struct {
+	release  string
+	platform string
+	test     string
+	count    int
+	top      int
+	output   string
+}
+
+var baselineHistoryArgs baselineHistoryInput
+var baselineHistoryCmd = &cobra.Command{
+	Use:     "history",
+	Example: "opct adm baseline history --release 4.15 --platform None --test '\\[sig-network\\].*'",
+	Short:   "Show the failure history of the tests across the baseline results.",
+	Long: `Show the failure history of the tests across the baseline results of a release and platform type.
+	The history is built from the failures to review (after the filter pipeline) of the conformance
+	plugins in each baseline summary listed in the index, from the oldest to the newest.
+	The timeline shows the result of the test in each baseline, F: failed, .: not failed.
+	Without --test, the chronic failures ranking (tests failing most often) is shown.`,
+	Run: baselineHistoryCmdRun,
+}
+
+func init() {
+	baselineHistoryCmd.Flags().StringVar(&baselineHistoryArgs.release, "release", "", "OpenShift release of the baselines (x.y). Required.")
+	baselineHistoryCmd.Flags().StringVar(&baselineHistoryArgs.platform, "platform", "", "Platform type of the baselines. Example: AWS, External, None. Required.")
+	baselineHistoryCmd.Flags().StringVar(&baselineHistoryArgs.test, "test", "", "Test name, or regular expression, to show the history.")
+	baselineHistoryCmd.Flags().IntVar(&baselineHistoryArgs.count, "count", 0, "Number of most recent baselines to read. Default: all.")
+	baselineHistoryCmd.Flags().IntVar(&baselineHistoryArgs.top, "top", 20, "Number of tests in the chronic failures ranking. Zero shows all.")
+	baselineHistoryCmd.Flags().StringVarP(&baselineHistoryArgs.output, "output", "o", "table", "Output format. One of: table, json")
+
+	if err := baselineHistoryCmd.MarkFlagRequired("release"); err != nil {
+		log.Fatalf("Failed to mark flag as required: %v", err)
+	}
+	if err := baselineHistoryCmd.MarkFlagRequired("platform"); err != nil {
+		log.Fatalf("Failed to mark flag as required: %v", err)
+	}
+}
+
+func baselineHistoryCmdRun(cmd *cobra.Command, args []string) {
+	if baselineHistoryArgs.output != "table" && baselineHistoryArgs.output != "json" {
+		log.Fatalf("invalid output format %q, valid values: table, json", baselineHistoryArgs.output)
+	}
+	rb := newBaselineConfig(reb.DefaultStoreURL())
+	fh, err := rb.GetFailureHistory(baselineHistoryArgs.release, baselineHistoryArgs.platform, baselineHistoryArgs.count, plugin.ConformancePlugins)
+	if err != nil {
+		log.Fatalf("Failed to build the failure history: %v", err)
+	}
+
+	var tests []*reb.TestHistory
+	if baselineHistoryArgs.test != "" {
+		tests, err = fh.Match(baselineHistoryArgs.test)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		tests = fh.Chronic(baselineHistoryArgs.top)
+	}
+
+	if baselineHistoryArgs.output == "json" {
+		data, err := json.MarshalIndent(struct {
+			Release   string             `json:"release"`
+			Platform  string             `json:"platform"`
+			Baselines []string           `json:"baselines"`
+			Tests     []*reb.TestHistory `json:"tests"`
+		}{fh.Release, fh.Platform, fh.Baselines, tests}, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to encode the history to JSON: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	tb := table.NewWriter()
+	tb.SetOutputMirror(os.Stdout)
+	tb.SetTitle(fmt.Sprintf("Failure history: %s %s (%d baselines, %s -> %s)", fh.Release, fh.Platform,
+		len(fh.Baselines), fh.Baselines[0], fh.Baselines[len(fh.Baselines)-1]))
+	tb.AppendHeader(table.Row{"Rank", "Failures", "Timeline", "Last Failure", "Test Name"})
+	tb.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 5, WidthMax: 100},
+	})
+	for _, th := range tests {
+		tb.AppendRow(table.Row{th.Rank, fmt.Sprintf("%d/%d", th.Failures, th.Total), th.Timeline, th.LastFailure, th.Name})
+	}
+	tb.Render()
+	if baselineHistoryArgs.test != "" && len(tests) == 0 {
+		log.Infof("No failures of tests matching %q in the baselines.", baselineHistoryArgs.test)
+	}
+}
@@ -10,7 +10,10 @@ import (
 )
 
 type baselineIndexerInput struct {
-	force bool
+	force      bool
+	full       bool
+	dryRun     bool
+	signingKey string
 }
 
 var baselineIndexerArgs baselineIndexerInput
@@ -18,11 +21,22 @@ var baselineIndexerCmd = &cobra.Command{
 	Use:     "indexer",
 	Example: "opct adm baseline indexer",
 	Short:   "(Administrative usage) Rebuild the indexer for baseline in the backend.",
-	Run:     baselineIndexerCmdRun,
+	Long: `(Administrative usage) Rebuild the indexer for baseline in the backend.
+	The index is incremental, only the summaries added or changed (ETag or size) since
+	the last index are read, using the tags from the object metadata when available.
+	The SHA-256 of each summary is recorded in the index, verified when the summary is read
+	by 'get' and the report filter pipeline. With --signing-key, the hash is signed (ed25519)
+	and the detached signature is recorded in the index.
+	The indexer also checks the consistency of the store, reporting malformed summary keys
+	(not following {ocpVersion}_{platformType}_{timestamp}.json) and orphaned objects.`,
+	Run: baselineIndexerCmdRun,
 }
 
 func init() {
 	baselineListCmd.Flags().BoolVar(&baselineIndexerArgs.force, "force", false, "List all results.")
+	baselineIndexerCmd.Flags().BoolVar(&baselineIndexerArgs.full, "full", false, "Ignore the existing index, reading all the summaries.")
+	baselineIndexerCmd.Flags().BoolVar(&baselineIndexerArgs.dryRun, "dry-run", false, "Check the consistency and the changes in the index without updating the store.")
+	baselineIndexerCmd.Flags().StringVar(&baselineIndexerArgs.signingKey, "signing-key", "", "Private key (ed25519, PEM PKCS #8) to sign the summaries recorded in the index.")
 
 	// Simple 'check' for non-authorized users, the command will fail later as the user does not have AWS required permissions.
 	if baselineIndexerArgs.force && os.Getenv("OPCT_ENABLE_ADM_BASELINE") != "1" {
@@ -31,10 +45,35 @@ func init() {
 }
 
 func baselineIndexerCmdRun(cmd *cobra.Command, args []string) {
-	rb := reb.NewBaselineReportSummary()
-	err := rb.CreateBaselineIndex()
+	opts := &reb.IndexerOptions{
+		Full:   baselineIndexerArgs.full,
+		DryRun: baselineIndexerArgs.dryRun,
+	}
+	if baselineIndexerArgs.signingKey != "" {
+		key, err := reb.LoadSigningKey(baselineIndexerArgs.signingKey)
+		if err != nil {
+			log.Fatalf("Failed to load the signing key: %v", err)
+		}
+		opts.SigningKey = key
+	}
+	rb := newBaselineConfig(reb.DefaultAdminStoreURL())
+	report, err := rb.CreateBaselineIndex(opts)
 	if err != nil {
 		log.Fatalf("Failed to read index from bucket: %v", err)
 	}
+	log.Infof("Index summary: added(%d) updated(%d) unchanged(%d) removed(%d)",
+		len(report.Added), len(report.Updated), len(report.Unchanged), len(report.Removed))
+	for _, key := range report.Removed {
+		log.Infof("Removed from index: %s", key)
+	}
+	for _, key := range report.Malformed {
+		log.Warnf("Malformed object: %s", key)
+	}
+	for _, key := range report.Orphaned {
+		log.Warnf("Orphaned object: %s", key)
+	}
+	if baselineIndexerArgs.dryRun {
+		return
+	}
 	log.Info("Indexer has been updated.")
 }
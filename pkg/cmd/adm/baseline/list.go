@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"sort"
+	"strings"
 
 	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
 
@@ -12,7 +13,8 @@ import (
 )
 
 type baselineListInput struct {
-	all bool
+	all      bool
+	identity string
 }
 
 var baselineListArgs baselineListInput
@@ -25,6 +27,7 @@ var baselineListCmd = &cobra.Command{
 
 func init() {
 	baselineListCmd.Flags().BoolVar(&baselineListArgs.all, "all", false, "List all results, instead of latest.")
+	baselineListCmd.Flags().StringVar(&baselineListArgs.identity, "identity", "", "List the results which identity ({uuid}:{archive hash}) contains the value. Implies --all.")
 
 	if baselineListArgs.all && os.Getenv("OPCT_ENABLE_ADM_BASELINE") != "1" {
 		log.Fatal("You are not allowed to execute this command.")
@@ -32,7 +35,7 @@ func init() {
 }
 
 func baselineListCmdRun(cmd *cobra.Command, args []string) {
-	rb := reb.NewBaselineReportSummary()
+	rb := newBaselineConfig(reb.DefaultStoreURL())
 	index, err := rb.ReadReportSummaryIndexFromAPI()
 	if err != nil {
 		log.Fatalf("Failed to read index from bucket: %v", err)
@@ -42,7 +45,7 @@ func baselineListCmdRun(cmd *cobra.Command, args []string) {
 	tb.SetOutputMirror(os.Stdout)
 	// tbProv.SetStyle(table.StyleLight)
 	// tbProv.SetTitle(title)
-	if !baselineListArgs.all {
+	if !baselineListArgs.all && baselineListArgs.identity == "" {
 		tb.AppendHeader(table.Row{"ID", "Type", "Release", "PlatformType", "Name"})
 		laltestK := make([]string, 0, len(index.Latest))
 		for lts := range index.Latest {
@@ -63,9 +66,12 @@ func baselineListCmdRun(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	tb.AppendHeader(table.Row{"Latest", "Release", "Platform", "Provider", "Name", "Version"})
+	tb.AppendHeader(table.Row{"Latest", "Release", "Platform", "Provider", "Name", "Version", "Identity", "Lifecycle"})
 	for i := range index.Results {
 		res := index.Results[i]
+		if baselineListArgs.identity != "" && !strings.Contains(res.Identity, baselineListArgs.identity) {
+			continue
+		}
 		latest := ""
 		if res.IsLatest {
 			latest = "*"
@@ -78,6 +84,13 @@ func baselineListCmdRun(cmd *cobra.Command, args []string) {
 		if p, ok := res.Tags["openshiftVersion"]; ok {
 			version = p.(string)
 		}
+		lifecycle := []string{}
+		if res.IsPinned() {
+			lifecycle = append(lifecycle, reb.TagPinned)
+		}
+		if res.IsDeprecated() {
+			lifecycle = append(lifecycle, reb.TagDeprecated)
+		}
 		tb.AppendRow(
 			table.Row{
 				latest,
@@ -86,6 +99,8 @@ func baselineListCmdRun(cmd *cobra.Command, args []string) {
 				provider,
 				res.Name,
 				version,
+				res.Identity,
+				strings.Join(lifecycle, ","),
 			})
 	}
 	tb.Render()
@@ -0,0 +1,54 @@
+package baseline
+
+import (
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type baselinePinInput struct {
+	unset  bool
+	dryRun bool
+}
+
+var baselinePinArgs baselinePinInput
+var baselinePinCmd = &cobra.Command{
+	Use:     "pin <baseline name>",
+	Example: "opct adm baseline pin 4.15_None_20240101000000",
+	Short:   "(Administrative usage) Pin a baseline result.",
+	Long: `(Administrative usage) Pin a baseline result.
+	Pinned results are never removed by 'opct adm baseline prune', and are preferred by the
+	indexer as the latest result of the release and platform type.`,
+	Args: cobra.ExactArgs(1),
+	Run:  baselinePinCmdRun,
+}
+
+func init() {
+	baselinePinCmd.Flags().BoolVar(&baselinePinArgs.unset, "unset", false, "Unpin the result.")
+	baselinePinCmd.Flags().BoolVar(&baselinePinArgs.dryRun, "dry-run", false, "Print the planned change without changing the store.")
+}
+
+func baselinePinCmdRun(cmd *cobra.Command, args []string) {
+	value := "true"
+	if baselinePinArgs.unset {
+		value = ""
+	}
+	setLifecycleTag(args[0], reb.TagPinned, value, baselinePinArgs.dryRun)
+}
+
+// setLifecycleTag sets the lifecycle tag of the result, updating the index.
+func setLifecycleTag(name, tag, value string, dryRun bool) {
+	rb := newBaselineConfig(reb.DefaultAdminStoreURL())
+	change := "set " + tag + "=" + value
+	if value == "" {
+		change = "unset " + tag
+	}
+	if dryRun {
+		log.Warnf("DRY-RUN mode: result %s would be changed in %s: %s", name, rb.GetStore(), change)
+		return
+	}
+	if err := rb.SetLifecycleTag(name, tag, value); err != nil {
+		log.Fatalf("Failed to %s in result %s: %v", change, name, err)
+	}
+	log.Infof("Result %s has been changed (%s), and the index has been updated.", name, change)
+}
@@ -0,0 +1,77 @@
+package baseline
+
+import (
+	"os"
+	"time"
+
+	table "github.com/jedib0t/go-pretty/v6/table"
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type baselinePruneInput struct {
+	keepLast  int
+	olderThan time.Duration
+	release   string
+	platform  string
+	dryRun    bool
+}
+
+var baselinePruneArgs baselinePruneInput
+var baselinePruneCmd = &cobra.Command{
+	Use:     "prune",
+	Example: "opct adm baseline prune --keep-last 5 --older-than 2160h --dry-run",
+	Short:   "(Administrative usage) Remove baseline results by retention policies.",
+	Long: `(Administrative usage) Remove baseline results by retention policies.
+	A result is removed when it is not in the last N results of the release and platform type
+	(--keep-last), or when it is older than the duration (--older-than).
+	Pinned results (see 'opct adm baseline pin'), and the latest result of each release and
+	platform type, are always kept. The summary and the archive are removed, and the index is updated.`,
+	Run: baselinePruneCmdRun,
+}
+
+func init() {
+	baselinePruneCmd.Flags().IntVar(&baselinePruneArgs.keepLast, "keep-last", 0, "Number of most recent results kept by release and platform type. Zero disables the policy.")
+	baselinePruneCmd.Flags().DurationVar(&baselinePruneArgs.olderThan, "older-than", 0, "Remove results older than the duration. Example: 2160h (90 days). Zero disables the policy.")
+	baselinePruneCmd.Flags().StringVar(&baselinePruneArgs.release, "release", "", "Limit the policies to the release. Example: 4.15")
+	baselinePruneCmd.Flags().StringVar(&baselinePruneArgs.platform, "platform", "", "Limit the policies to the platform type. Example: External")
+	baselinePruneCmd.Flags().BoolVar(&baselinePruneArgs.dryRun, "dry-run", false, "Print the results planned to be removed without changing the store.")
+}
+
+func baselinePruneCmdRun(cmd *cobra.Command, args []string) {
+	if baselinePruneArgs.keepLast <= 0 && baselinePruneArgs.olderThan <= 0 {
+		log.Fatal("at least one policy must be set: --keep-last or --older-than")
+	}
+	rb := newBaselineConfig(reb.DefaultAdminStoreURL())
+	items, err := rb.PlanPrune(&reb.PrunePolicy{
+		KeepLast:  baselinePruneArgs.keepLast,
+		OlderThan: baselinePruneArgs.olderThan,
+		Release:   baselinePruneArgs.release,
+		Platform:  baselinePruneArgs.platform,
+	})
+	if err != nil {
+		log.Fatalf("Failed to plan the prune: %v", err)
+	}
+	if len(items) == 0 {
+		log.Info("No results to remove.")
+		return
+	}
+
+	tb := table.NewWriter()
+	tb.SetOutputMirror(os.Stdout)
+	tb.AppendHeader(table.Row{"Release", "Platform", "Name", "Date", "Reason"})
+	for _, item := range items {
+		tb.AppendRow(table.Row{item.Release, item.Platform, item.Name, item.Date, item.Reason})
+	}
+	tb.Render()
+
+	if baselinePruneArgs.dryRun {
+		log.Warnf("DRY-RUN mode: %d results would be removed from %s", len(items), rb.GetStore())
+		return
+	}
+	if err := rb.Prune(items); err != nil {
+		log.Fatalf("Failed to prune the results: %v", err)
+	}
+	log.Infof("%d results have been removed, and the index has been updated.", len(items))
+}
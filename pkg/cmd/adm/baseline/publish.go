@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/metrics"
 	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
@@ -20,6 +21,8 @@ type baselinePublishInput struct {
 	forceLatest bool
 	verbose     bool
 	dryRun      bool
+	replace     bool
+	policy      string
 }
 
 var baselinePublishArgs baselinePublishInput
@@ -29,7 +32,9 @@ var baselinePublishCmd = &cobra.Command{
 	Short:   "Publish a baseline result to be used in the review process.",
 	Long: `Publish a baseline result to be used in the review process.
 	Baseline results are used to compare the results of the validation tests.
-	Publishing a baseline result is useful when you want to share the baseline with other users.`,
+	Publishing a baseline result is useful when you want to share the baseline with other users.
+	The baseline identity is built from the sonobuoy run UUID and the archive hash (SHA-256),
+	publishing an archive already published is refused, unless --replace is set.`,
 	Run: baselinePublishCmdRun,
 }
 
@@ -46,6 +51,14 @@ func init() {
 		&baselinePublishArgs.dryRun, "dry-run", false,
 		"Process the data and skip publishing the baseline.",
 	)
+	baselinePublishCmd.Flags().BoolVar(
+		&baselinePublishArgs.replace, "replace", false,
+		"Replace the baseline when the archive has already been published (same identity).",
+	)
+	baselinePublishCmd.Flags().StringVar(
+		&baselinePublishArgs.policy, "policy", "",
+		"Acceptance policy file (YAML) with the blocking and warning check IDs, and sanity thresholds. Default: blocking checks OPCT-001, OPCT-004, OPCT-005 and OPCT-022.",
+	)
 }
 
 func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
@@ -53,9 +66,7 @@ func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
 		log.Warn("argument --force-latest <result_name> must be set. Check available baseline with 'opct adm baseline list'")
 	}
 	// TODOs
-	// - check if the baseline exists
 	// - read and process as regular 'report' command
-	// - check sanity: counts should have acceptable, etc
 	// - extract the data to be published, building the name of the file and attributes.
 	if len(args) == 0 {
 		log.Fatalf("result archive not found: %v", args)
@@ -65,6 +76,21 @@ func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
 		log.Fatalf("archive not found: %v", archive)
 	}
 
+	policy := report.NewDefaultAcceptancePolicy()
+	if baselinePublishArgs.policy != "" {
+		p, err := report.LoadAcceptancePolicyFromFile(baselinePublishArgs.policy)
+		if err != nil {
+			log.Fatalf("error loading acceptance policy: %v", err)
+		}
+		policy = p
+	}
+
+	archiveHash, err := baseline.ArchiveHash(archive)
+	if err != nil {
+		log.Fatalf("error calculating the archive hash: %v", err)
+	}
+	brs := newBaselineConfig(baseline.DefaultAdminStoreURL())
+
 	fmt.Println()
 	log.Infof("Processing baseline result for %s", filepath.Base(archive))
 
@@ -72,16 +98,19 @@ func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
 	timers.Add("report-total")
 
 	saveDirectory := "/tmp/opct-tmp-results-" + filepath.Base(archive)
-	err := os.Setenv("OPCT_DISABLE_FILTER_BASELINE", "1")
+	err = os.Setenv("OPCT_DISABLE_FILTER_BASELINE", "1")
 	if err != nil {
 		log.Fatalf("error setting variable OPCT_DISABLE_FILTER_BASELINE to skip baseline in the filter pipeline: %v", err)
 	}
-	cs := summary.NewConsolidatedSummary(&summary.ConsolidatedSummaryInput{
+	cs, err := summary.NewConsolidatedSummary(&summary.ConsolidatedSummaryInput{
 		Verbose: baselinePublishArgs.verbose,
 		Timers:  timers,
 		Archive: archive,
 		SaveTo:  saveDirectory,
 	})
+	if err != nil {
+		log.Fatalf("error creating the summary: %v", err)
+	}
 
 	log.Debug("Processing results")
 	if err := cs.Process(); err != nil {
@@ -94,6 +123,35 @@ func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
 		log.Errorf("error populating report: %v", err)
 	}
 
+	// Check if the baseline has already been published, using the identity
+	// of the archive: sonobuoy run UUID and archive hash.
+	re.Setup.API.ArchiveHash = archiveHash
+	re.Setup.API.Identity = baseline.NewBaselineIdentity(re.Setup.API.UUID, archiveHash)
+	log.Infof("Baseline identity: %s", re.Setup.API.Identity)
+	if existing := brs.FindBaselineByIdentity(re.Setup.API.Identity, re.Setup.API.SummaryName); len(existing) > 0 {
+		switch {
+		case baselinePublishArgs.replace:
+			log.Warnf("Baseline %s has already been published, replacing it (--replace): %v", re.Setup.API.Identity, existing)
+		case baselinePublishArgs.dryRun:
+			log.Warnf("DRY-RUN mode: baseline %s has already been published and would be rejected: %v", re.Setup.API.Identity, existing)
+		default:
+			log.Fatalf("baseline %s has already been published: %v. Use --replace to publish it again.", re.Setup.API.Identity, existing)
+		}
+	}
+
+	// Evaluate the acceptance policy, saving the evaluation in the summary and
+	// in the metadata (setup.api).
+	acceptance := policy.Evaluate(re)
+	re.Setup.Acceptance = acceptance
+	re.Setup.API.Acceptance = acceptance.Status
+	re.Setup.API.AcceptanceWarnings = strings.Join(acceptance.WarningIDs(), ",")
+	for _, w := range acceptance.Warnings {
+		log.Warnf("acceptance policy warning, check id %s: %s", w.ID, w.Message)
+	}
+	for _, r := range acceptance.Rejections {
+		log.Errorf("rejecting the baseline, check id %s: %s", r.ID, r.Message)
+	}
+
 	// TODO: ConsolidatedSummary should be migrated to SaveResults
 	if err := cs.SaveResults(saveDirectory); err != nil {
 		log.Errorf("error saving consolidated summary results: %v", err)
@@ -103,44 +161,18 @@ func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
 		log.Errorf("error saving report results: %v", err)
 	}
 
-	// TODO: move to config, or allow to add skips.
-	// Reject publish when those checks are failing:
-	// OPCT-001 : kube conformance failing
-	// OPCT-004 : too many tests failed on openshift conformance
-	// OPCT-003 : collector must be able to collect the results
-	// OPCT-007 (ERR missing must-gather): must-gather is missing
-	// OPCT-022: potential runtime failure
-	// TODO/Validate if need:
-	// OPCT-023*: Test sanity. Enable it when CI pipeline (periodic) is validated
-	// - etcd very slow
-	rejected := false
-	for _, check := range re.Checks.Fail {
-		if check.ID == report.CheckID001 ||
-			check.ID == report.CheckID004 ||
-			check.ID == report.CheckID005 ||
-			check.ID == report.CheckID022 {
-			errMessage := fmt.Sprintf("%q: want=%q, got=%q", check.SLO, check.SLITarget, check.SLIActual)
-			if check.Message != "" {
-				errMessage = fmt.Sprintf("%s: message=%q", errMessage, check.Message)
-			}
-			log.Errorf("rejecting the baseline, check id %s is in failed state: %s", check.ID, errMessage)
-			rejected = true
-			continue
-		}
-	}
-	if rejected {
-		log.Fatal("baseline rejected, see the logs for more details.")
+	if !acceptance.Accepted() {
+		log.Fatalf("baseline rejected by the acceptance policy %q, see the logs for more details.", acceptance.Policy)
 		return
 	}
 
-	checksStatus := fmt.Sprintf("pass(%d), fail(%d), warn(%d) skip(%d)", len(re.Checks.Pass), len(re.Checks.Fail), len(re.Checks.Warn), len(re.Checks.Skip))
-	log.Infof("Baseline checks are OK, proceeding to publish the baseline: %s", checksStatus)
+	checksStatus := fmt.Sprintf("pass(%d), fail(%d), warn(%d) skip(%d) waived(%d)", len(re.Checks.Pass), len(re.Checks.Fail), len(re.Checks.Warn), len(re.Checks.Skip), len(re.Checks.Waived))
+	log.Infof("Baseline checks are %s by the acceptance policy %q, proceeding to publish the baseline: %s", acceptance.Status, acceptance.Policy, checksStatus)
 
 	// Prepare the baseline to publish:
 	// - build the metadata from the original report (setup.api)
 	// - upload the artifact to /uploads
 	// - upload the summary to /api/v0/result/summary
-	brs := baseline.NewBaselineReportSummary()
 	metaBytes, err := json.Marshal(re.Setup.API)
 	if err != nil {
 		log.Errorf("error marshaling metadata: %v", err)
@@ -153,8 +185,6 @@ func baselinePublishCmdRun(cmd *cobra.Command, args []string) {
 	}
 	log.Infof("Baseline metadata: %v", meta)
 	log.Infof("Uploading baseline to storage")
-	// TODO: check if the baseline already exists. It should check the unique
-	// id other than the bucket name. The UUID is a good candidate.
 	err = brs.UploadBaseline(archive, saveDirectory, meta, baselinePublishArgs.dryRun)
 	if err != nil {
 		log.Fatalf("error uploading baseline: %v", err)
@@ -1,10 +1,20 @@
 package baseline
 
 import (
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// baselineStoreURL is the URL of the baseline store, when empty the command default
+// is used: results API to read, and the OPCT S3 bucket to write.
+var baselineStoreURL string
+
+// baselineIntegrityWarn and baselineVerifyKey are the options to verify the integrity
+// of the summaries read from the store.
+var baselineIntegrityWarn bool
+var baselineVerifyKey string
+
 var baselineCmd = &cobra.Command{
 	Use:   "baseline",
 	Short: "Administrative commands to manipulate baseline results.",
@@ -27,12 +37,49 @@ var baselineCmd = &cobra.Command{
 }
 
 func init() {
+	baselineCmd.PersistentFlags().StringVar(&baselineStoreURL, "store", "",
+		"URL of the baseline store. Supported schemes: s3://bucket[/prefix], s3+http(s)://host:port/bucket[/prefix] (S3-compatible/MinIO), "+
+			"http(s)://host[/path] (read-only results API), file:///path or local directory. "+
+			"Default: results API to read, OPCT storage to write.")
+	baselineCmd.PersistentFlags().BoolVar(&baselineIntegrityWarn, "integrity-warn", false,
+		"Log a warning, instead of failing, when a summary does not match the hash (or signature) recorded in the index. Summaries not verifiable (index without hash) are used with a warning.")
+	baselineCmd.PersistentFlags().StringVar(&baselineVerifyKey, "verify-key", "",
+		"Public key (ed25519, PEM) to verify the signatures of the summaries recorded in the index. Unsigned, or not verifiable, summaries are rejected.")
+
 	baselineCmd.AddCommand(baselineListCmd)
 	baselineCmd.AddCommand(baselineGetCmd)
 	baselineCmd.AddCommand(baselineIndexerCmd)
 	baselineCmd.AddCommand(baselinePublishCmd)
+	baselineCmd.AddCommand(baselineServeCmd)
+	baselineCmd.AddCommand(baselinePruneCmd)
+	baselineCmd.AddCommand(baselinePinCmd)
+	baselineCmd.AddCommand(baselineDeprecateCmd)
+	baselineCmd.AddCommand(baselineDiffCmd)
+	baselineCmd.AddCommand(baselineHistoryCmd)
 }
 
 func NewCmdBaseline() *cobra.Command {
 	return baselineCmd
 }
+
+// newBaselineConfig creates the baseline config with the store set by --store,
+// or the defaultURL when not set, verifying the summaries read from the store.
+func newBaselineConfig(defaultURL string) *reb.BaselineConfig {
+	storeURL := baselineStoreURL
+	if storeURL == "" {
+		storeURL = defaultURL
+	}
+	rb, err := reb.NewBaselineReportSummaryFromURL(storeURL)
+	if err != nil {
+		log.Fatalf("Failed to create baseline store: %v", err)
+	}
+	integrity := &reb.IntegrityOptions{WarnOnly: baselineIntegrityWarn}
+	if baselineVerifyKey != "" {
+		integrity.PublicKey, err = reb.LoadVerifyKey(baselineVerifyKey)
+		if err != nil {
+			log.Fatalf("Failed to load the verify key: %v", err)
+		}
+	}
+	rb.SetIntegrityOptions(integrity)
+	return rb
+}
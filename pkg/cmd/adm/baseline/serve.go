@@ -0,0 +1,77 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	reb "github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report/baseline"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type baselineServeInput struct {
+	address string
+}
+
+var baselineServeArgs baselineServeInput
+var baselineServeCmd = &cobra.Command{
+	Use:     "serve [directory]",
+	Example: "opct adm baseline serve /tmp/baselines --address 0.0.0.0:8080",
+	Short:   "Serve the Baseline API from a local directory.",
+	Long: `Serve the Baseline API from a local directory, or from the store set by --store.
+	The directory must follow the layout of the baseline store, created by
+	'opct adm baseline publish --store <directory>' and 'opct adm baseline indexer --store <directory>'.
+	The API is served under /api/v0, example: /api/v0/result/summary/index.json.
+	Use 'opct report --baseline-api-url http://<address>/api/v0' to use it in the filter pipeline,
+	allowing disconnected environments to use the Baseline API filter.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  baselineServeCmdRun,
+}
+
+func init() {
+	baselineServeCmd.Flags().StringVar(&baselineServeArgs.address, "address", "0.0.0.0:8080", "Address to listen the Baseline API server.")
+}
+
+func baselineServeCmdRun(cmd *cobra.Command, args []string) {
+	storeURL := baselineStoreURL
+	if len(args) > 0 {
+		storeURL = args[0]
+	}
+	if storeURL == "" {
+		log.Fatal("the directory or --store must be set.")
+	}
+	rb, err := reb.NewBaselineReportSummaryFromURL(storeURL)
+	if err != nil {
+		log.Fatalf("Failed to create baseline store: %v", err)
+	}
+	if _, err := rb.ReadReportSummaryIndexFromAPI(); err != nil {
+		log.Warnf("Index not found in the store %s, run 'opct adm baseline indexer --store %s' to create it.", rb.GetStore(), storeURL)
+	}
+
+	srv := &http.Server{
+		Addr:              baselineServeArgs.address,
+		Handler:           reb.NewAPIHandler(rb.GetStore()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("error shutting down the Baseline API server: %v", err)
+		}
+	}()
+
+	log.Infof("Serving the Baseline API from %s at http://%s/api/v0", rb.GetStore(), baselineServeArgs.address)
+	log.Infof("Use it in the report with: opct report --baseline-api-url http://%s/api/v0 <archive>", baselineServeArgs.address)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Unable to start the Baseline API server at address %s: %v", baselineServeArgs.address, err)
+	}
+}
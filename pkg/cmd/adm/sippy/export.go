@@ -0,0 +1,80 @@
+package sippy
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/ci/sippy"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type sippyExportInput struct {
+	cacheDir string
+	releases []string
+	tests    []string
+	output   string
+}
+
+var sippyExportArgs sippyExportInput
+var sippyExportCmd = &cobra.Command{
+	Use:     "export",
+	Example: "opct adm sippy export --release 4.15 --output sippy-4.15.json",
+	Short:   "Export the flake data cache to a snapshot file.",
+	Long: `Export the flake data cache to a snapshot file.
+	The cache is populated when running 'opct report' with internet access. The
+	option --test can be used to query and cache the tests before exporting.`,
+	Run: sippyExportCmdRun,
+}
+
+func init() {
+	sippyExportCmd.Flags().StringVar(&sippyExportArgs.cacheDir, "cache-dir", "", "Directory of the flake data cache. Default: "+sippy.DefaultCacheDir())
+	sippyExportCmd.Flags().StringSliceVar(&sippyExportArgs.releases, "release", []string{}, "Release(s) to export. Default: all releases in the cache. Example: 4.15")
+	sippyExportCmd.Flags().StringSliceVar(&sippyExportArgs.tests, "test", []string{}, "Test name(s) to query and cache before exporting. Requires --release.")
+	sippyExportCmd.Flags().StringVarP(&sippyExportArgs.output, "output", "o", "", "Snapshot file path. Required.")
+
+	if err := sippyExportCmd.MarkFlagRequired("output"); err != nil {
+		log.Fatalf("Error marking flag 'output' as required: %v", err)
+	}
+}
+
+func sippyExportCmdRun(cmd *cobra.Command, args []string) {
+	if len(sippyExportArgs.tests) > 0 {
+		if len(sippyExportArgs.releases) == 0 {
+			log.Fatal("argument --release must be set when --test is used")
+		}
+		for _, release := range sippyExportArgs.releases {
+			api := sippy.NewSippyAPIWithOptions(release, &sippy.Options{CacheDir: sippyExportArgs.cacheDir})
+			for _, test := range sippyExportArgs.tests {
+				if _, err := api.QueryTests(&sippy.SippyTestsRequestInput{TestName: test}); err != nil {
+					log.Errorf("unable to query test %q for release %s: %v", test, release, err)
+				}
+			}
+			if err := api.SaveCache(); err != nil {
+				log.Fatalf("unable to save the cache: %v", err)
+			}
+		}
+	}
+
+	cache := sippy.NewCache(sippyExportArgs.cacheDir, 0)
+	snap, err := cache.Export(sippyExportArgs.releases)
+	if err != nil {
+		log.Fatalf("unable to export the cache from %s: %v", cache.Dir(), err)
+	}
+	if len(snap.Releases) == 0 {
+		log.Fatalf("no flake data found in the cache directory %s. Run 'opct report' with internet access to populate it.", cache.Dir())
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		log.Fatalf("unable to encode the snapshot: %v", err)
+	}
+	if err := os.WriteFile(sippyExportArgs.output, data, 0644); err != nil {
+		log.Fatalf("unable to write the snapshot file: %v", err)
+	}
+	count := 0
+	for _, r := range snap.Releases {
+		count += len(r.Tests)
+	}
+	log.Infof("Snapshot with %d tests from %d release(s) saved to %s", count, len(snap.Releases), sippyExportArgs.output)
+}
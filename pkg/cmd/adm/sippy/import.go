@@ -0,0 +1,42 @@
+package sippy
+
+import (
+	"time"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/openshift/ci/sippy"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type sippyImportInput struct {
+	cacheDir string
+}
+
+var sippyImportArgs sippyImportInput
+var sippyImportCmd = &cobra.Command{
+	Use:     "import snapshot.json",
+	Example: "opct adm sippy import sippy-4.15.json",
+	Short:   "Import a flake data snapshot file to the cache.",
+	Long: `Import a flake data snapshot file to the cache.
+	The imported data is used by 'opct report' in disconnected environments, the
+	flag --sippy-offline can be used to prevent reaching the API.`,
+	Run:  sippyImportCmdRun,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	sippyImportCmd.Flags().StringVar(&sippyImportArgs.cacheDir, "cache-dir", "", "Directory of the flake data cache. Default: "+sippy.DefaultCacheDir())
+}
+
+func sippyImportCmdRun(cmd *cobra.Command, args []string) {
+	snap, err := sippy.LoadSnapshotFromFile(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	cache := sippy.NewCache(sippyImportArgs.cacheDir, 0)
+	count, err := cache.Import(snap)
+	if err != nil {
+		log.Fatalf("unable to import the snapshot: %v", err)
+	}
+	log.Infof("Imported %d tests from snapshot created at %s to %s", count, snap.CreatedAt.Format(time.RFC3339), cache.Dir())
+}
@@ -0,0 +1,32 @@
+package sippy
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var sippyCmd = &cobra.Command{
+	Use:   "sippy",
+	Short: "Administrative commands to manage the flake data (Sippy) cache.",
+	Long: `Administrative commands to manage the flake data (Sippy) cache.
+	The flake filter in 'opct report' queries the OpenShift CI (Sippy) API for
+	each failed test, keeping the responses in a persistent on-disk cache.
+	The cache can be exported to a snapshot file and imported in disconnected
+	environments, allowing consistent report results without internet access.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			if err := cmd.Help(); err != nil {
+				log.Errorf("error loading help(): %v", err)
+			}
+		}
+	},
+}
+
+func init() {
+	sippyCmd.AddCommand(sippyExportCmd)
+	sippyCmd.AddCommand(sippyImportCmd)
+}
+
+func NewCmdSippy() *cobra.Command {
+	return sippyCmd
+}
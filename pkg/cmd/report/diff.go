@@ -0,0 +1,105 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/vmware-tanzu/sonobuoy/pkg/errlog"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/metrics"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+func newCmdDiff() *cobra.Command {
+	data := Input{}
+	output := report.DiffFormatTable
+	cmd := &cobra.Command{
+		Use:   "diff archiveA.tar.gz|opct-report.json archiveB.tar.gz|opct-report.json",
+		Short: "Compare the results of two archives.",
+		Long: `Compare the results of two archives, from A (before) to B (after).
+Both archives are processed by the filter pipeline, or read from the report data saved
+by 'opct report --save-to' (opct-report.json). The comparison shows the cluster
+version and infrastructure changes, the plugin counters, the new, resolved and still
+failing tests (after filters), the checks which result changed, the error counters
+changed, and the etcd slow requests latency.`,
+		Example: `opct report diff monday.tar.gz today.tar.gz -o html > diff.html`,
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if err := diffArchives(ctx, &data, args[0], args[1], output); err != nil {
+				errlog.LogError(errors.Wrapf(err, "could not compare archives %s and %s", args[0], args[1]))
+				os.Exit(report.ExitCodeError)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(
+		&output, "output", "o", report.DiffFormatTable,
+		fmt.Sprintf("Output format. One of: %s", strings.Join(report.DiffFormats, ", ")),
+	)
+	addFilterPipelineFlags(cmd, &data)
+	return cmd
+}
+
+// loadReport builds the report data with the checks from the result archive, or
+// reads the report data from a JSON file (opct-report.json) saved by 'opct report --save-to'.
+func loadReport(ctx context.Context, input *Input, path string) (*report.ReportData, error) {
+	if strings.HasSuffix(path, ".json") {
+		return report.LoadReportDataFromFile(path)
+	}
+	in := *input
+	in.archive = path
+	cs, err := processArchive(ctx, &in, metrics.NewTimers())
+	if err != nil {
+		return nil, err
+	}
+	re := report.NewReportData(false)
+	if err := re.Populate(cs); err != nil {
+		return nil, fmt.Errorf("error populating report: %v", err)
+	}
+	return re, nil
+}
+
+// diffArchives processes both archives and shows the comparison in the output format.
+func diffArchives(ctx context.Context, input *Input, archiveA, archiveB, output string) error {
+	valid := false
+	for _, f := range report.DiffFormats {
+		valid = valid || f == output
+	}
+	if !valid {
+		return fmt.Errorf("invalid output format %q, valid values: %s", output, strings.Join(report.DiffFormats, ", "))
+	}
+
+	reA, err := loadReport(ctx, input, archiveA)
+	if err != nil {
+		return fmt.Errorf("error processing %s: %w", archiveA, err)
+	}
+	reB, err := loadReport(ctx, input, archiveB)
+	if err != nil {
+		return fmt.Errorf("error processing %s: %w", archiveB, err)
+	}
+
+	diff := report.NewReportDiff(archiveA, reA, archiveB, reB)
+	switch output {
+	case report.DiffFormatJSON:
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode the diff to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	case report.DiffFormatMarkdown:
+		diff.RenderMarkdown(os.Stdout)
+	case report.DiffFormatHTML:
+		return diff.RenderHTML(os.Stdout)
+	default:
+		diff.RenderTable(os.Stdout)
+	}
+	return nil
+}
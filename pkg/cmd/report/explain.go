@@ -0,0 +1,155 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	table "github.com/jedib0t/go-pretty/v6/table"
+	tabletext "github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/vmware-tanzu/sonobuoy/pkg/errlog"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/metrics"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/plugin"
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/opct/summary"
+)
+
+func newCmdExplain() *cobra.Command {
+	data := Input{}
+	cmd := &cobra.Command{
+		Use:   "explain archive.tar.gz test-name",
+		Short: "Explain the filter pipeline decisions to a failed test.",
+		Long: `Explain the filter pipeline decisions to a failed test, showing the decision
+of each filter (kept or excluded), the reason and the evidence (Sippy flake percentage,
+baseline name, replay result, etc).
+The test name is matched exactly, or by substring when there is no exact match.`,
+		Example: `opct report explain archive.tar.gz "[sig-storage] CSI Volumes should mount"`,
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			data.archive = args[0]
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if err := explainTest(ctx, &data, args[1]); err != nil {
+				errlog.LogError(errors.Wrapf(err, "could not explain test %q", args[1]))
+				os.Exit(1)
+			}
+		},
+	}
+	addFilterPipelineFlags(cmd, &data)
+	return cmd
+}
+
+// explainTest processes the archive and shows the filter decisions of the tests matching the name.
+func explainTest(ctx context.Context, input *Input, testName string) error {
+	cs, err := processArchive(ctx, input, metrics.NewTimers())
+	if err != nil {
+		return err
+	}
+
+	matches := findFailuresByName(cs, testName)
+	if len(matches) == 0 {
+		return fmt.Errorf("test not found in the failures of the plugins")
+	}
+	names := map[string]struct{}{}
+	for _, m := range matches {
+		names[m.test.Name] = struct{}{}
+	}
+	if len(names) > 1 {
+		candidates := []string{}
+		for name := range names {
+			candidates = append(candidates, name)
+		}
+		sort.Strings(candidates)
+		fmt.Printf("Found %d failures matching %q:\n", len(candidates), testName)
+		for _, name := range candidates {
+			fmt.Printf(" - %s\n", name)
+		}
+		return fmt.Errorf("multiple tests matched, use the exact test name")
+	}
+	for _, m := range matches {
+		showTestDecisions(m.plugin, m.test)
+	}
+	return nil
+}
+
+// explainMatch is a failed test found in a plugin.
+type explainMatch struct {
+	plugin *plugin.OPCTPluginSummary
+	test   *plugin.TestItem
+}
+
+// findFailuresByName looks up the failures by the exact name on each plugin,
+// falling back to the substring match.
+func findFailuresByName(cs *summary.ConsolidatedSummary, testName string) []*explainMatch {
+	lookup := func(match func(name string) bool) []*explainMatch {
+		matches := []*explainMatch{}
+		for _, pluginName := range plugin.ConformancePlugins {
+			ps := cs.GetProvider().GetOpenShift().GetResultByName(pluginName)
+			if ps == nil {
+				continue
+			}
+			for _, name := range ps.FailedList {
+				test, ok := ps.Tests[name]
+				if !ok || !match(name) {
+					continue
+				}
+				matches = append(matches, &explainMatch{plugin: ps, test: test})
+			}
+		}
+		return matches
+	}
+	if matches := lookup(func(name string) bool { return name == testName }); len(matches) > 0 {
+		return matches
+	}
+	lowerName := strings.ToLower(testName)
+	return lookup(func(name string) bool { return strings.Contains(strings.ToLower(name), lowerName) })
+}
+
+// showTestDecisions shows the decisions of the filter pipeline to the test.
+func showTestDecisions(ps *plugin.OPCTPluginSummary, test *plugin.TestItem) {
+	st := table.StyleLight
+	st.Options.SeparateRows = true
+
+	tb := table.NewWriter()
+	tb.SetOutputMirror(os.Stdout)
+	tb.SetStyle(st)
+	tb.SetTitle(fmt.Sprintf("==> %s\n%s", ps.Name, test.Name))
+	tb.AppendHeader(table.Row{"#", "Filter", "Decision", "Reason", "Evidence"})
+	tb.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 4, WidthMax: 50},
+		{Number: 5, WidthMax: 40},
+	})
+
+	result := "kept in the final list of failures to review"
+	for idx, d := range test.Decisions {
+		decision := d.Decision
+		if d.Decision == plugin.FilterDecisionExcluded {
+			decision = tabletext.Bold.Sprint(d.Decision)
+			result = fmt.Sprintf("excluded by filter %q", d.Filter)
+		}
+		tb.AppendRow(table.Row{idx + 1, d.Filter, decision, d.Reason, formatEvidence(d.Evidence)})
+	}
+	tb.AppendFooter(table.Row{"", "Result", "", result, ""})
+	tb.Render()
+	fmt.Println()
+}
+
+// formatEvidence builds the evidence list sorted by key.
+func formatEvidence(evidence map[string]string) string {
+	keys := []string{}
+	for k := range evidence {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := []string{}
+	for _, k := range keys {
+		items = append(items, fmt.Sprintf("%s=%s", k, evidence[k]))
+	}
+	return strings.Join(items, "\n")
+}
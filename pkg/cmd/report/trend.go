@@ -0,0 +1,87 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/vmware-tanzu/sonobuoy/pkg/errlog"
+
+	"github.com/redhat-openshift-ecosystem/provider-certification-tool/internal/report"
+)
+
+func newCmdTrend() *cobra.Command {
+	data := Input{}
+	output := report.TrendFormatTable
+	cmd := &cobra.Command{
+		Use:   "trend archive.tar.gz|opct-report.json...",
+		Short: "Show the trend of multiple results.",
+		Long: `Show the trend of multiple results, ordered by execution date: the pass ratio and
+the failures after filters by plugin, the check results, the etcd slow requests p99 latency,
+and the error counters.
+Tests failing in some runs but not others are reported as intermittent failures, pointing
+to environment instability.
+Results are result archives, processed by the filter pipeline, or the report data saved
+by 'opct report --save-to' (opct-report.json).`,
+		Example: `opct report trend run1/opct-report.json run2/opct-report.json run3.tar.gz -o html > trend.html`,
+		Args:    cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if err := showTrend(ctx, &data, args, output); err != nil {
+				errlog.LogError(errors.Wrap(err, "could not build the trend"))
+				os.Exit(report.ExitCodeError)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(
+		&output, "output", "o", report.TrendFormatTable,
+		fmt.Sprintf("Output format. One of: %s", strings.Join(report.TrendFormats, ", ")),
+	)
+	addFilterPipelineFlags(cmd, &data)
+	return cmd
+}
+
+// showTrend loads the results and shows the trend in the output format.
+func showTrend(ctx context.Context, input *Input, paths []string, output string) error {
+	valid := false
+	for _, f := range report.TrendFormats {
+		valid = valid || f == output
+	}
+	if !valid {
+		return fmt.Errorf("invalid output format %q, valid values: %s", output, strings.Join(report.TrendFormats, ", "))
+	}
+
+	reports := []*report.ReportData{}
+	for _, path := range paths {
+		re, err := loadReport(ctx, input, path)
+		if err != nil {
+			return fmt.Errorf("error processing %s: %w", path, err)
+		}
+		reports = append(reports, re)
+	}
+
+	trend, err := report.NewReportTrend(paths, reports)
+	if err != nil {
+		return err
+	}
+	switch output {
+	case report.TrendFormatJSON:
+		data, err := json.MarshalIndent(trend, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode the trend to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	case report.TrendFormatHTML:
+		return trend.RenderHTML(os.Stdout)
+	default:
+		trend.RenderTable(os.Stdout)
+	}
+	return nil
+}